@@ -0,0 +1,150 @@
+package oca
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/mhdiiilham/oca/query"
+)
+
+// Unlock releases an advisory lock taken with Lock or TryLock and returns
+// its connection to the pool. When the release fails, or reports that the
+// lock was not held, the connection is discarded instead so the lock cannot
+// stay behind on a pooled session; the error wraps ErrLockNotHeld in the
+// latter case.
+type Unlock func(ctx context.Context) error
+
+// TryLock attempts to take the session advisory lock on key without waiting,
+// using the active dialect (pg_try_advisory_lock on Postgres, GET_LOCK on
+// MySQL and MariaDB). The lock lives on a dedicated connection that is held
+// until Unlock is called, so it is always released by the session that owns it.
+//
+// When another session holds the lock, TryLock returns false and a nil Unlock.
+//
+// Example:
+//
+//	unlock, ok, err := oca.TryLock(ctx, db, "nightly-report")
+//	if err != nil || !ok {
+//	    return err // another replica is running it
+//	}
+//	defer unlock(context.Background())
+func TryLock(ctx context.Context, db *sql.DB, key string) (Unlock, bool, error) {
+	return lock(ctx, db, key, true)
+}
+
+// Lock takes the session advisory lock on key, waiting until it is available
+// or ctx is done. Like TryLock, the lock is pinned to a dedicated connection
+// until Unlock is called. When the database reports that the lock was not
+// taken (GET_LOCK returning 0 or NULL), Lock returns ErrLockNotHeld.
+func Lock(ctx context.Context, db *sql.DB, key string) (Unlock, error) {
+	unlock, _, err := lock(ctx, db, key, false)
+	return unlock, err
+}
+
+// TryLockTx attempts to take a transaction-scoped advisory lock on key
+// without waiting. The lock is released when tx commits or rolls back.
+// The dialect must support query.FeatureAdvisoryXactLock (Postgres).
+func TryLockTx(ctx context.Context, tx *sql.Tx, key string) (bool, error) {
+	return queryLock(ctx, tx, query.AdvisoryLock(key).Try().Xact(), true)
+}
+
+// LockTx takes a transaction-scoped advisory lock on key, waiting until it is
+// available. The lock is released when tx commits or rolls back.
+// The dialect must support query.FeatureAdvisoryXactLock (Postgres).
+func LockTx(ctx context.Context, tx *sql.Tx, key string) error {
+	_, err := queryLock(ctx, tx, query.AdvisoryLock(key).Xact(), false)
+	return err
+}
+
+// lock takes a session advisory lock on a dedicated connection.
+func lock(ctx context.Context, db *sql.DB, key string, try bool) (Unlock, bool, error) {
+	b := query.AdvisoryLock(key)
+	if try {
+		b.Try()
+	}
+	// resolve the unlock statement up front so a failure cannot strand the lock
+	unlockSQL, unlockArgs, err := query.AdvisoryUnlock(key).SQL()
+	if err != nil {
+		return nil, false, err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ok, err := queryLock(ctx, conn, b, try)
+	if err != nil || !ok {
+		_ = conn.Close()
+		return nil, false, err
+	}
+
+	unlock := func(ctx context.Context) error {
+		defer conn.Close()
+		var released any
+		err := conn.QueryRowContext(ctx, unlockSQL, unlockArgs...).Scan(&released)
+		if err == nil && !lockAcquired(released) {
+			err = fmt.Errorf("%w: release of %q", ErrLockNotHeld, key)
+		}
+		if err != nil {
+			discard(conn)
+		}
+		return err
+	}
+	return unlock, true, nil
+}
+
+// discard closes the physical connection behind conn instead of returning it
+// to the pool, dropping any session lock it may still hold.
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+}
+
+// queryLock runs a lock statement and reports whether the lock was acquired.
+// A blocking lock that was not acquired is an error wrapping ErrLockNotHeld.
+func queryLock(ctx context.Context, db DBTX, b *query.AdvisoryLockBuilder, try bool) (bool, error) {
+	sqlStr, args, err := b.SQL()
+	if err != nil {
+		return false, err
+	}
+
+	var result any
+	if err := db.QueryRowContext(ctx, sqlStr, args...).Scan(&result); err != nil {
+		return false, err
+	}
+	switch {
+	case try:
+		return lockAcquired(result), nil
+	case voidLock(query.GetDialect()):
+		return true, nil
+	case !lockAcquired(result):
+		return false, fmt.Errorf("%w: lock on %v", ErrLockNotHeld, args[0])
+	}
+	return true, nil
+}
+
+// voidLock reports whether the blocking lock functions of d return void,
+// failing with an error rather than a result (pg_advisory_lock).
+func voidLock(d query.Dialect) bool {
+	_, pg := d.(query.PostgresDialect)
+	return pg
+}
+
+// lockAcquired interprets the result of a lock or unlock call: a boolean on
+// Postgres, 1 (success), 0 (busy or not held) or NULL (error) on MySQL.
+func lockAcquired(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case int64:
+		return v == 1
+	case []byte:
+		return lockAcquired(string(v))
+	case string:
+		return v == "1" || v == "t" || v == "true"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,158 @@
+package oca_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryLock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT GET_LOCK\(\?, 0\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(int64(1)))
+	mock.ExpectQuery(`SELECT RELEASE_LOCK\(\?\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"release"}).AddRow(int64(1)))
+
+	unlock, ok, err := oca.TryLock(ctx, db, "cron")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, unlock(ctx))
+
+	mock.ExpectQuery(`SELECT GET_LOCK\(\?, 0\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(int64(0)))
+
+	unlock, ok, err = oca.TryLock(ctx, db, "cron")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, unlock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT GET_LOCK\(\?, -1\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(int64(1)))
+	mock.ExpectQuery(`SELECT RELEASE_LOCK\(\?\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"release"}).AddRow(int64(1)))
+
+	unlock, err := oca.Lock(ctx, db, "cron")
+	assert.NoError(t, err)
+	assert.NoError(t, unlock(ctx))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLock_NotAcquired(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	// GET_LOCK returns NULL on errors such as the session being killed
+	mock.ExpectQuery(`SELECT GET_LOCK\(\?, -1\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(nil))
+
+	unlock, err := oca.Lock(ctx, db, "cron")
+	assert.ErrorIs(t, err, oca.ErrLockNotHeld)
+	assert.Nil(t, unlock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnlock_NotHeld(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT GET_LOCK\(\?, -1\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(int64(1)))
+	mock.ExpectQuery(`SELECT RELEASE_LOCK\(\?\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"release"}).AddRow(int64(0)))
+
+	unlock, err := oca.Lock(ctx, db, "cron")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, unlock(ctx), oca.ErrLockNotHeld)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// the connection was discarded rather than returned to the pool
+	assert.Equal(t, 0, db.Stats().Idle)
+}
+
+func TestLock_Postgres(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT pg_advisory_lock\(hashtext\(\$1\)\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(""))
+	mock.ExpectQuery(`SELECT pg_advisory_unlock\(hashtext\(\$1\)\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"release"}).AddRow(true))
+
+	unlock, err := oca.Lock(ctx, db, "cron")
+	assert.NoError(t, err)
+	assert.NoError(t, unlock(ctx))
+	assert.Equal(t, 1, db.Stats().Idle)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTryLockTx_Postgres(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(hashtext\(\$1\)\)`).
+		WithArgs("cron").
+		WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(true))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	ok, err := oca.TryLockTx(ctx, tx, "cron")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockTx_Unsupported(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	err = oca.LockTx(context.Background(), tx, "cron")
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+}
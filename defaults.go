@@ -0,0 +1,57 @@
+package oca
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mhdiiilham/oca/query"
+)
+
+// Schema defaults
+//
+// A field tagged `schema:"default:<expr>"` that holds its zero value on
+// insert is filled by the database. Where the dialect supports
+// INSERT ... RETURNING (query.FeatureInsertReturning), the column is then
+// returned with the auto fields, so the entity reflects what was stored.
+// Supported expressions:
+//
+//	default:now()       current timestamp
+//	default:uuid()      random UUID
+//	default:'literal'   string literal, with '' for a quote
+//	default:0           decimal number, such as -1 or 2.5
+//
+// Functions are rendered by the active dialect (see query.DefaultFuncDialect).
+
+// defaultExpr renders the SQL for a `default:` schema expression.
+func defaultExpr(d query.Dialect, expr string) (query.RawSQL, error) {
+	switch {
+	case strings.HasSuffix(expr, "()"):
+		if fd, ok := d.(query.DefaultFuncDialect); ok {
+			if fn, ok := fd.DefaultFunc(strings.TrimSuffix(expr, "()")); ok {
+				return query.Raw(fn), nil
+			}
+		}
+		return "", fmt.Errorf("oca: default %s is not supported on %s", expr, d.Name())
+	case isQuotedLiteral(expr):
+		return query.Raw(expr), nil
+	case decimalLiteral.MatchString(expr):
+		return query.Raw(expr), nil
+	default:
+		return "", fmt.Errorf("oca: unsupported default %q", expr)
+	}
+}
+
+// decimalLiteral matches a plain decimal number. strconv.ParseFloat would
+// also accept NaN, Inf, exponents and hex floats, which are not portable SQL.
+var decimalLiteral = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// isQuotedLiteral reports whether s is a single-quoted SQL string whose
+// inner quotes are all doubled.
+func isQuotedLiteral(s string) bool {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return false
+	}
+	inner := s[1 : len(s)-1]
+	return !strings.Contains(strings.ReplaceAll(inner, "''", ""), "'")
+}
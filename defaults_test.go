@@ -0,0 +1,36 @@
+package oca
+
+import (
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultExpr(t *testing.T) {
+	tests := []struct {
+		dialect query.Dialect
+		expr    string
+		want    query.RawSQL
+	}{
+		{query.MySQLDialect{}, "now()", "CURRENT_TIMESTAMP"},
+		{query.MySQLDialect{}, "uuid()", "UUID()"},
+		{query.MariaDBDialect{}, "uuid()", "UUID()"},
+		{query.PostgresDialect{}, "now()", "CURRENT_TIMESTAMP"},
+		{query.PostgresDialect{}, "uuid()", "gen_random_uuid()"},
+		{query.PostgresDialect{}, "'draft'", "'draft'"},
+		{query.PostgresDialect{}, "'it''s'", "'it''s'"},
+		{query.PostgresDialect{}, "0", "0"},
+		{query.PostgresDialect{}, "-1.5", "-1.5"},
+	}
+	for _, tt := range tests {
+		got, err := defaultExpr(tt.dialect, tt.expr)
+		assert.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, got, tt.expr)
+	}
+
+	for _, expr := range []string{"random()", "draft", "'it's'", "1; DROP TABLE users", "", "NaN", "Inf", "0x1p-2", "1e3", "1.", "+1"} {
+		_, err := defaultExpr(query.PostgresDialect{}, expr)
+		assert.Error(t, err, expr)
+	}
+}
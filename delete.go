@@ -0,0 +1,176 @@
+package oca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/mhdiiilham/oca/query"
+)
+
+// Delete removes the record identified by the primary key of entity.
+// If T has a soft delete field, the row is kept and the field is set to the
+// current time instead, both in the database and on entity.
+//
+// Example:
+//
+//	err := repo.Delete(ctx, &post)
+func (r *Repository[T]) Delete(ctx context.Context, entity *T) error {
+	where, err := primaryKeyCondition(entity)
+	if err != nil {
+		return err
+	}
+	if err := r.runHook(ctx, hookBeforeDelete, entity); err != nil {
+		return err
+	}
+
+	if sd, ok := softDeleteField(reflect.TypeOf(entity)); ok {
+		err = r.softDelete(ctx, entity, sd, where)
+	} else {
+		err = r.exec(ctx, query.Delete(r.tableName()).Where(where))
+	}
+	if err != nil {
+		return err
+	}
+	return r.runHook(ctx, hookAfterDelete, entity)
+}
+
+// softDelete sets the soft delete field of the record matching where.
+func (r *Repository[T]) softDelete(ctx context.Context, entity *T, sd FieldMeta, where query.Condition) error {
+	field := reflect.ValueOf(entity).Elem().FieldByIndex(sd.index())
+	if err := checkSoftDeleteField(sd, field.Type()); err != nil {
+		return err
+	}
+	now := r.now()
+	b := query.Update(r.tableName()).
+		Set(sd.Column, now).
+		Where(where, query.C(sd.Column).IsNull())
+	if err := r.exec(ctx, b); err != nil {
+		return err
+	}
+	setTime(field, now)
+	return nil
+}
+
+// ForceDelete removes the record identified by the primary key of entity,
+// even if T is soft-deleted.
+func (r *Repository[T]) ForceDelete(ctx context.Context, entity *T) error {
+	where, err := primaryKeyCondition(entity)
+	if err != nil {
+		return err
+	}
+	if err := r.runHook(ctx, hookBeforeDelete, entity); err != nil {
+		return err
+	}
+	if err := r.exec(ctx, query.Delete(r.tableName()).Where(where)); err != nil {
+		return err
+	}
+	return r.runHook(ctx, hookAfterDelete, entity)
+}
+
+// Restore clears the soft delete field of the record identified by the
+// primary key of entity, both in the database and on entity.
+// It returns ErrNoSoftDelete if T has no soft delete field.
+func (r *Repository[T]) Restore(ctx context.Context, entity *T) error {
+	where, err := primaryKeyCondition(entity)
+	if err != nil {
+		return err
+	}
+
+	sd, ok := softDeleteField(reflect.TypeOf(entity))
+	if !ok {
+		return ErrNoSoftDelete
+	}
+	field := reflect.ValueOf(entity).Elem().FieldByIndex(sd.index())
+	if err := checkSoftDeleteField(sd, field.Type()); err != nil {
+		return err
+	}
+	b := query.Update(r.tableName()).
+		Set(sd.Column, nil).
+		Where(where)
+	if err := r.exec(ctx, b); err != nil {
+		return err
+	}
+	field.Set(reflect.Zero(field.Type()))
+	return nil
+}
+
+// DeleteReturning removes the records matching the filter and returns them
+// as they were before removal. It relies on DELETE ... RETURNING, so the
+// active dialect must support it (Postgres, MariaDB); otherwise the error
+// wraps query.ErrUnsupported.
+//
+// If T has a soft delete field, the matching rows that are not yet deleted
+// are soft-deleted instead with UPDATE ... RETURNING, which requires
+// query.FeatureUpdateReturning (Postgres). Those rows are returned as they
+// are after the update, with the soft delete field set.
+//
+// Example:
+//
+//	removed, err := repo.DeleteReturning(ctx, oca.Where(query.C("expires_at").Lt(now)))
+func (r *Repository[T]) DeleteReturning(ctx context.Context, opts ...FilterOptions) ([]T, error) {
+	var entity T
+	filter := newFilter(opts...)
+	if err := checkDeleteFilter(filter); err != nil {
+		return nil, err
+	}
+
+	var builder statement
+	if sd, ok := softDeleteField(reflect.TypeOf(entity)); ok {
+		if err := checkSoftDeleteField(sd, reflect.TypeOf(entity).FieldByIndex(sd.index()).Type); err != nil {
+			return nil, err
+		}
+		b := query.Update(r.tableName()).
+			Set(sd.Column, r.now()).
+			Where(filter.Where...).
+			Where(query.C(sd.Column).IsNull()).
+			Returning(getColumnNames(entity)...)
+		if filter.Order != "" {
+			b.OrderBy(filter.Order)
+		}
+		if filter.Limit > 0 {
+			b.Limit(filter.Limit)
+		}
+		builder = b
+	} else {
+		b := query.Delete(r.tableName()).Returning(getColumnNames(entity)...)
+		applyDeleteFilters(b, filter)
+		builder = b
+	}
+
+	sqlStr, args, err := builder.SQL()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.conn(ctx).QueryContext(ctx, sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRows[T](rows)
+}
+
+// checkDeleteFilter rejects filter options that have no meaning for DELETE:
+// OFFSET and row locks.
+func checkDeleteFilter(f FindFilter) error {
+	if f.Offset > 0 {
+		return errors.New("oca: OFFSET is not supported on delete")
+	}
+	if f.ForUpdate || f.ForShare || f.SkipLocked || f.NoWait {
+		return errors.New("oca: row locks are not supported on delete")
+	}
+	return nil
+}
+
+// applyDeleteFilters applies WHERE, ORDER BY and LIMIT to a delete builder.
+func applyDeleteFilters(b *query.DeleteBuilder, f FindFilter) {
+	b.Where(f.Where...)
+	if f.Order != "" {
+		b.OrderBy(f.Order)
+	}
+	if f.Limit > 0 {
+		b.Limit(f.Limit)
+	}
+}
@@ -0,0 +1,52 @@
+package oca_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_DeleteReturning(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Todo](db)
+
+	now := time.Now()
+	mock.ExpectQuery(`DELETE FROM todos WHERE created_at < \$1 RETURNING id, title, created_at`).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "created_at"}).
+			AddRow(1, "Old 1", now.Add(-time.Hour)).
+			AddRow(2, "Old 2", now.Add(-2*time.Hour)))
+
+	removed, err := repo.DeleteReturning(context.Background(), oca.Where(query.C("created_at").Lt(now)))
+	assert.NoError(t, err)
+	assert.Len(t, removed, 2)
+	assert.Equal(t, int64(1), removed[0].ID)
+	assert.Equal(t, "Old 2", removed[1].Title)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_DeleteReturning_Unsupported(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Todo](db)
+
+	_, err = repo.DeleteReturning(context.Background(), oca.Where(query.C("id").Eq(1)))
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+
+	_, err = repo.DeleteReturning(context.Background(), oca.Offset(10))
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
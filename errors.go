@@ -0,0 +1,29 @@
+package oca
+
+import "errors"
+
+// ErrLockOutsideTx is returned when a row lock (FOR UPDATE, FOR SHARE) is
+// requested outside a transaction. In autocommit mode the lock would be
+// released as soon as the statement finishes, so it would protect nothing.
+var ErrLockOutsideTx = errors.New("oca: row lock requires a transaction")
+
+// ErrNoPrimaryKey is returned when an operation needs to identify a single
+// record but T has no field tagged `db:"...,pk"`.
+var ErrNoPrimaryKey = errors.New("oca: model has no primary key")
+
+// ErrNoSoftDelete is returned by Restore when T has no field tagged
+// `schema:"soft_delete"`.
+var ErrNoSoftDelete = errors.New("oca: model has no soft delete field")
+
+// ErrStaleObject is returned by Update when the record's version column no
+// longer matches the entity, meaning it was changed or deleted since it was
+// read. Reload the record and retry.
+var ErrStaleObject = errors.New("oca: stale object")
+
+// ErrInvalidKey is returned when primary key values passed to FindByPK or
+// FindByPKs do not match the number or types of the primary key fields.
+var ErrInvalidKey = errors.New("oca: invalid primary key")
+
+// ErrLockNotHeld is returned when an advisory lock could not be taken, or when
+// releasing it reports that the session did not hold it.
+var ErrLockNotHeld = errors.New("oca: advisory lock not held")
@@ -14,6 +14,14 @@ type FindFilter struct {
 	Order  string            // ORDER BY clause
 	Limit  int               // LIMIT
 	Offset int               // OFFSET
+
+	ForUpdate  bool // FOR UPDATE
+	ForShare   bool // FOR SHARE
+	SkipLocked bool // SKIP LOCKED, with ForUpdate or ForShare
+	NoWait     bool // NOWAIT, with ForUpdate or ForShare
+
+	WithTrashed bool // include soft-deleted records
+	OnlyTrashed bool // only soft-deleted records
 }
 
 // FilterOptions modifies a FindFilter.
@@ -45,10 +53,47 @@ func Offset(offset int) FilterOptions {
 	return func(ff *FindFilter) { ff.Offset = offset }
 }
 
+// ForUpdate locks the selected rows for writing until the transaction ends.
+// It is only allowed on a repository created with NewTxRepository or inside
+// an ambient transaction (see InTx); otherwise the query fails with
+// ErrLockOutsideTx.
+func ForUpdate() FilterOptions {
+	return func(ff *FindFilter) { ff.ForUpdate = true }
+}
+
+// ForShare locks the selected rows against writes until the transaction ends.
+// Like ForUpdate, it requires a transaction-bound repository.
+func ForShare() FilterOptions {
+	return func(ff *FindFilter) { ff.ForShare = true }
+}
+
+// SkipLocked skips rows already locked by another transaction.
+// It is used together with ForUpdate or ForShare.
+func SkipLocked() FilterOptions {
+	return func(ff *FindFilter) { ff.SkipLocked = true }
+}
+
+// NoWait fails instead of waiting for rows locked by another transaction.
+// It is used together with ForUpdate or ForShare.
+func NoWait() FilterOptions {
+	return func(ff *FindFilter) { ff.NoWait = true }
+}
+
 func defaultFilter() FindFilter {
 	return FindFilter{}
 }
 
+// newFilter returns the default filter with opts applied.
+func newFilter(opts ...FilterOptions) FindFilter {
+	filter := defaultFilter()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&filter)
+		}
+	}
+	return filter
+}
+
 // Finds retrieves multiple records from the database matching the filter.
 // It automatically maps database rows to struct fields based on the `db` tags.
 // Finds retrieves records from the database based on provided filter options.
@@ -56,25 +101,37 @@ func defaultFilter() FindFilter {
 func (r *Repository[T]) Finds(ctx context.Context, opts ...FilterOptions) ([]T, error) {
 	var entity T
 	// Build the SQL query
-	builder := query.From(resolveTableName(entity)).Select(getColumnNames(entity)...)
-
-	filter := defaultFilter()
-	for _, opt := range opts {
-		if opt != nil {
-			opt(&filter)
-		}
-	}
+	builder := query.From(r.tableName()).Select(getColumnNames(entity)...)
 
+	filter := newFilter(opts...)
 	applyFilters(builder, filter)
+	if scope, ok := softDeleteScope[T](filter); ok {
+		builder.Where(scope)
+	}
+	if builder.Locking() && !r.inTransaction(ctx) {
+		return nil, ErrLockOutsideTx
+	}
 
-	sqlStr, args := builder.Build()
-	rows, err := r.db.QueryContext(ctx, sqlStr, args...)
+	sqlStr, args, err := builder.SQL()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.conn(ctx).QueryContext(ctx, sqlStr, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query error: %w", err)
 	}
 	defer rows.Close()
 
-	return scanRows[T](rows)
+	results, err := scanRows[T](rows)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		if err := r.runHook(ctx, hookAfterFind, &results[i]); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
 }
 
 // FindOne retrieves a single record from the database based on the filter.
@@ -93,7 +150,31 @@ func (r *Repository[T]) FindOne(ctx context.Context, opts ...FilterOptions) (*T,
 	return &results[0], nil
 }
 
-// applyFilters applies WHERE, ORDER BY, LIMIT, OFFSET to the query builder
+// Count returns the number of records matching the filter. ORDER BY, LIMIT
+// and OFFSET are ignored.
+//
+// Example:
+//
+//	n, err := repo.Count(ctx, oca.Where(query.C("status").Eq("active")))
+func (r *Repository[T]) Count(ctx context.Context, opts ...FilterOptions) (int64, error) {
+	filter := newFilter(opts...)
+	builder := query.From(r.tableName()).SelectExpr(query.Count("*")).Where(filter.Where...)
+	if scope, ok := softDeleteScope[T](filter); ok {
+		builder.Where(scope)
+	}
+
+	sqlStr, args, err := builder.SQL()
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	if err := r.conn(ctx).QueryRowContext(ctx, sqlStr, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("query error: %w", err)
+	}
+	return n, nil
+}
+
+// applyFilters applies WHERE, ORDER BY, LIMIT, OFFSET and row locks to the query builder
 func applyFilters(b *query.Builder, f FindFilter) {
 	for _, cond := range f.Where {
 		b.Where(cond)
@@ -107,4 +188,16 @@ func applyFilters(b *query.Builder, f FindFilter) {
 	if f.Offset > 0 {
 		b.Offset(f.Offset)
 	}
+	if f.ForUpdate {
+		b.ForUpdate()
+	}
+	if f.ForShare {
+		b.ForShare()
+	}
+	if f.SkipLocked {
+		b.SkipLocked()
+	}
+	if f.NoWait {
+		b.NoWait()
+	}
 }
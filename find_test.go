@@ -102,3 +102,46 @@ func TestRepository_FindOne_NotFound(t *testing.T) {
 	assert.Nil(t, todo)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+type BaseModel struct {
+	ID        int64     `db:"id,pk,auto"`
+	CreatedAt time.Time `db:"created_at" schema:"autoCreateTime"`
+}
+
+type Address struct {
+	City string `db:"city"`
+	Zip  string `db:"zip"`
+}
+
+type Store struct {
+	BaseModel
+	Name    string  `db:"name"`
+	Address Address `db:"addr_,inline"`
+}
+
+func TestRepository_EmbeddedFields(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := oca.NewRepository[Store](db, oca.WithClock(oca.ClockFunc(func() time.Time { return now })))
+
+	mock.ExpectQuery(`INSERT INTO stores \(created_at, name, addr_city, addr_zip\) VALUES \(\?, \?, \?, \?\) RETURNING id`).
+		WithArgs(now, "Corner", "Oslo", "0150").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectQuery(`SELECT id, created_at, name, addr_city, addr_zip FROM stores WHERE addr_city = \?`).
+		WithArgs("Oslo").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "name", "addr_city", "addr_zip"}).
+			AddRow(7, now, "Corner", "Oslo", "0150"))
+
+	s := &Store{Name: "Corner", Address: Address{City: "Oslo", Zip: "0150"}}
+	assert.NoError(t, repo.Insert(context.Background(), s))
+	assert.Equal(t, int64(7), s.ID)
+	assert.Equal(t, now, s.CreatedAt)
+
+	stores, err := repo.Finds(context.Background(), oca.Where(query.C("addr_city").Eq("Oslo")))
+	assert.NoError(t, err)
+	assert.Equal(t, []Store{*s}, stores)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
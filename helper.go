@@ -1,9 +1,12 @@
 package oca
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/mhdiiilham/oca/query"
 )
 
 // resolveTableName returns the table name either from Tabler or from struct name.
@@ -31,7 +34,7 @@ func buildScanTargets(val reflect.Value, fields []FieldMeta) ([]interface{}, err
 
 	targets := make([]interface{}, len(fields))
 	for i, f := range fields {
-		field := val.Field(f.Index)
+		field := val.FieldByIndex(f.index())
 		if !field.CanAddr() {
 			return nil, fmt.Errorf("buildScanTargets: field %s is not addressable", f.Name)
 		}
@@ -39,3 +42,37 @@ func buildScanTargets(val reflect.Value, fields []FieldMeta) ([]interface{}, err
 	}
 	return targets, nil
 }
+
+// primaryKeyCondition returns "pk = ?" for the primary key fields of entity,
+// joined with AND for composite keys.
+func primaryKeyCondition(entity any) (query.Condition, error) {
+	var conds []query.Condition
+	for _, f := range parseFields(entity) {
+		if f.IsPrimary {
+			conds = append(conds, query.C(f.Column).Eq(f.Value))
+		}
+	}
+	switch len(conds) {
+	case 0:
+		return query.Condition{}, ErrNoPrimaryKey
+	case 1:
+		return conds[0], nil
+	default:
+		return query.And(conds...), nil
+	}
+}
+
+// statement is implemented by the query builders.
+type statement interface {
+	SQL() (string, []any, error)
+}
+
+// exec builds and runs a statement that returns no rows.
+func (r *Repository[T]) exec(ctx context.Context, b statement) error {
+	sqlStr, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+	_, err = r.conn(ctx).ExecContext(ctx, sqlStr, args...)
+	return err
+}
@@ -0,0 +1,105 @@
+package oca
+
+import "context"
+
+// Lifecycle hooks
+//
+// T (usually through pointer receivers) may implement any of the interfaces
+// below. Repository methods call them around each write and after each
+// read. An error from a Before hook aborts the statement; an error from an
+// After hook is returned after the statement ran, so run the call in a
+// transaction (InTx) to roll it back. Hooks receive the ambient transaction
+// through ctx (see TxFromContext).
+//
+//	func (u *User) BeforeInsert(ctx context.Context) error {
+//	    u.Email = strings.ToLower(strings.TrimSpace(u.Email))
+//	    return nil
+//	}
+
+// BeforeInserter is called by Insert, InsertMany and Upsert before the row is written.
+type BeforeInserter interface {
+	BeforeInsert(ctx context.Context) error
+}
+
+// AfterInserter is called by Insert, InsertMany and Upsert after the row is
+// written and auto fields are populated.
+type AfterInserter interface {
+	AfterInsert(ctx context.Context) error
+}
+
+// BeforeUpdater is called by Update before the row is written.
+type BeforeUpdater interface {
+	BeforeUpdate(ctx context.Context) error
+}
+
+// AfterUpdater is called by Update after the row is written.
+type AfterUpdater interface {
+	AfterUpdate(ctx context.Context) error
+}
+
+// BeforeDeleter is called by Delete, ForceDelete and DeleteByID before the
+// row is removed or soft-deleted.
+type BeforeDeleter interface {
+	BeforeDelete(ctx context.Context) error
+}
+
+// AfterDeleter is called by Delete, ForceDelete and DeleteByID after the row
+// is removed or soft-deleted.
+type AfterDeleter interface {
+	AfterDelete(ctx context.Context) error
+}
+
+// AfterFinder is called for every record loaded by Finds, FindOne and the
+// primary key lookups.
+type AfterFinder interface {
+	AfterFind(ctx context.Context) error
+}
+
+// hook names a lifecycle hook.
+type hook int
+
+const (
+	hookBeforeInsert hook = iota
+	hookAfterInsert
+	hookBeforeUpdate
+	hookAfterUpdate
+	hookBeforeDelete
+	hookAfterDelete
+	hookAfterFind
+)
+
+// runHook calls hook h on entity when it implements it.
+func (r *Repository[T]) runHook(ctx context.Context, h hook, entity *T) error {
+	ctx = r.hookContext(ctx)
+	switch h {
+	case hookBeforeInsert:
+		if e, ok := any(entity).(BeforeInserter); ok {
+			return e.BeforeInsert(ctx)
+		}
+	case hookAfterInsert:
+		if e, ok := any(entity).(AfterInserter); ok {
+			return e.AfterInsert(ctx)
+		}
+	case hookBeforeUpdate:
+		if e, ok := any(entity).(BeforeUpdater); ok {
+			return e.BeforeUpdate(ctx)
+		}
+	case hookAfterUpdate:
+		if e, ok := any(entity).(AfterUpdater); ok {
+			return e.AfterUpdate(ctx)
+		}
+	case hookBeforeDelete:
+		if e, ok := any(entity).(BeforeDeleter); ok {
+			return e.BeforeDelete(ctx)
+		}
+	case hookAfterDelete:
+		if e, ok := any(entity).(AfterDeleter); ok {
+			return e.AfterDelete(ctx)
+		}
+	case hookAfterFind:
+		if e, ok := any(entity).(AfterFinder); ok {
+			return e.AfterFind(ctx)
+		}
+	}
+	return nil
+}
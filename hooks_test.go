@@ -0,0 +1,154 @@
+package oca_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/stretchr/testify/assert"
+)
+
+type Account struct {
+	ID    int64  `db:"id,pk"`
+	Email string `db:"email"`
+
+	calls []string
+	inTx  bool
+}
+
+func (a *Account) record(ctx context.Context, name string) {
+	a.calls = append(a.calls, name)
+	_, a.inTx = oca.TxFromContext(ctx)
+}
+
+func (a *Account) BeforeInsert(ctx context.Context) error {
+	a.record(ctx, "BeforeInsert")
+	if a.Email == "" {
+		return errors.New("email is required")
+	}
+	a.Email = strings.ToLower(a.Email)
+	return nil
+}
+
+func (a *Account) AfterInsert(ctx context.Context) error {
+	a.record(ctx, "AfterInsert")
+	return nil
+}
+
+func (a *Account) BeforeUpdate(ctx context.Context) error {
+	a.record(ctx, "BeforeUpdate")
+	return nil
+}
+
+func (a *Account) AfterUpdate(ctx context.Context) error {
+	a.record(ctx, "AfterUpdate")
+	return nil
+}
+
+func (a *Account) BeforeDelete(ctx context.Context) error {
+	a.record(ctx, "BeforeDelete")
+	return nil
+}
+
+func (a *Account) AfterFind(ctx context.Context) error {
+	a.record(ctx, "AfterFind")
+	return nil
+}
+
+func TestRepository_Hooks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Account](db)
+	ctx := context.Background()
+	acc := &Account{ID: 1, Email: "Alice@Example.com"}
+
+	mock.ExpectExec(`INSERT INTO accounts \(id, email\) VALUES \(\?, \?\)`).
+		WithArgs(int64(1), "alice@example.com").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	assert.NoError(t, repo.Insert(ctx, acc))
+
+	mock.ExpectExec(`UPDATE accounts SET email = \? WHERE id = \?`).
+		WithArgs("alice@example.com", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Update(ctx, acc))
+
+	mock.ExpectExec(`DELETE FROM accounts WHERE id = \?`).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Delete(ctx, acc))
+
+	assert.Equal(t, []string{"BeforeInsert", "AfterInsert", "BeforeUpdate", "AfterUpdate", "BeforeDelete"}, acc.calls)
+	assert.False(t, acc.inTx)
+
+	mock.ExpectQuery(`SELECT id, email FROM accounts`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "a@example.com").AddRow(2, "b@example.com"))
+	found, err := repo.Finds(ctx)
+	assert.NoError(t, err)
+	for _, a := range found {
+		assert.Equal(t, []string{"AfterFind"}, a.calls)
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_BeforeHookAbortsWrite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = oca.NewRepository[Account](db).Insert(context.Background(), &Account{ID: 1})
+	assert.EqualError(t, err, "email is required")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInTx_HooksSeeAmbientTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Account](db)
+	acc := &Account{ID: 1, Email: "a@example.com"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO accounts`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = oca.InTx(context.Background(), db, func(ctx context.Context) error {
+		return repo.Insert(ctx, acc)
+	})
+	assert.NoError(t, err)
+	assert.True(t, acc.inTx)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	err = oca.InTx(context.Background(), db, func(ctx context.Context) error {
+		return repo.Insert(ctx, &Account{ID: 2})
+	})
+	assert.EqualError(t, err, "email is required")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInTx_LockUsesAmbientTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, email FROM accounts FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}))
+	mock.ExpectCommit()
+
+	repo := oca.NewRepository[Account](db)
+	err = oca.InTx(context.Background(), db, func(ctx context.Context) error {
+		_, err := repo.Finds(ctx, oca.ForUpdate())
+		return err
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
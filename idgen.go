@@ -0,0 +1,242 @@
+package oca
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ID generators
+//
+// A field tagged `schema:"gen:<name>"` is filled by the named generator on
+// Insert and InsertMany when it holds its zero value:
+//
+//	type Event struct {
+//	    ID   string `db:"id,pk" schema:"gen:uuidv7"`
+//	    Name string `db:"name"`
+//	}
+//
+// Built-in generators:
+//
+//	uuidv4     random UUID string
+//	uuidv7     time-ordered UUID string
+//	ulid       26-character time-ordered ULID string
+//	snowflake  time-ordered int64
+
+var (
+	generatorsMu sync.RWMutex
+	generators   = map[string]func() (any, error){
+		"uuidv4":    func() (any, error) { return newUUIDv4() },
+		"uuidv7":    func() (any, error) { return newUUIDv7() },
+		"ulid":      func() (any, error) { return newULID() },
+		"snowflake": nextSnowflake,
+	}
+)
+
+// randRead fills b with random bytes; tests replace it to simulate failures.
+var randRead = rand.Read
+
+// RegisterIDGenerator registers gen under name for `schema:"gen:name"`
+// fields, replacing any generator with the same name. The generated value
+// must be assignable or convertible to the field type.
+//
+// Example:
+//
+//	oca.RegisterIDGenerator("nanoid", func() any { return nanoid.Must() })
+func RegisterIDGenerator(name string, gen func() any) {
+	RegisterIDGeneratorE(name, func() (any, error) { return gen(), nil })
+}
+
+// RegisterIDGeneratorE is like RegisterIDGenerator for generators that can
+// fail; an error from gen fails the insert.
+//
+// Example:
+//
+//	oca.RegisterIDGeneratorE("nanoid", func() (any, error) { return gonanoid.New() })
+func RegisterIDGeneratorE(name string, gen func() (any, error)) {
+	generatorsMu.Lock()
+	defer generatorsMu.Unlock()
+	generators[name] = gen
+}
+
+func lookupIDGenerator(name string) (func() (any, error), bool) {
+	generatorsMu.RLock()
+	defer generatorsMu.RUnlock()
+	gen, ok := generators[name]
+	return gen, ok
+}
+
+// generateIDs fills the zero-valued `gen:` fields of entity.
+func generateIDs(entity any) error {
+	val := reflect.ValueOf(entity).Elem()
+	for _, m := range getStructMeta(val.Type()) {
+		name, ok := m.Schema["gen"]
+		if !ok {
+			continue
+		}
+		field := val.FieldByIndex(m.index())
+		if !field.IsZero() {
+			continue
+		}
+		gen, ok := lookupIDGenerator(name)
+		if !ok {
+			return fmt.Errorf("oca: unknown id generator %q for field %s", name, m.Name)
+		}
+
+		v, err := gen()
+		if err != nil {
+			return fmt.Errorf("oca: id generator %q: %w", name, err)
+		}
+		id := reflect.ValueOf(v)
+		switch {
+		case !id.IsValid():
+			return fmt.Errorf("oca: id generator %q returned nil", name)
+		case id.Type().AssignableTo(field.Type()):
+			field.Set(id)
+		case id.Type().ConvertibleTo(field.Type()):
+			field.Set(id.Convert(field.Type()))
+		default:
+			return fmt.Errorf("oca: id generator %q returned %s, field %s is %s", name, id.Type(), m.Name, field.Type())
+		}
+	}
+	return nil
+}
+
+// newUUIDv4 returns a random (version 4) UUID.
+func newUUIDv4() (string, error) {
+	var u [16]byte
+	if _, err := randRead(u[:]); err != nil {
+		return "", err
+	}
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u), nil
+}
+
+// newUUIDv7 returns a time-ordered (version 7) UUID: a 48-bit Unix
+// millisecond timestamp followed by random bits.
+func newUUIDv7() (string, error) {
+	var u [16]byte
+	if _, err := randRead(u[6:]); err != nil {
+		return "", err
+	}
+	ms := uint64(time.Now().UnixMilli())
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	u[6] = u[6]&0x0f | 0x70
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u), nil
+}
+
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID returns a ULID: a 48-bit Unix millisecond timestamp and 80 random
+// bits, encoded as 26 Crockford base32 characters.
+func newULID() (string, error) {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	if _, err := randRead(b[6:]); err != nil {
+		return "", err
+	}
+
+	// 128 bits as 26 groups of 5 bits, the first holding the top 3 bits
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:]), nil
+}
+
+// snowflakeEpoch is the start of the snowflake timestamp (2020-01-01 UTC).
+var snowflakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+// snowflake generates 63-bit ids: 41 bits of milliseconds since
+// snowflakeEpoch, a 10-bit node and a 12-bit per-millisecond sequence.
+type snowflake struct {
+	mu   sync.Mutex
+	node int64
+	last int64
+	seq  int64
+}
+
+// defaultSnowflake uses a random node, so separate processes are unlikely
+// to collide. Register a generator with a fixed node where that matters.
+var defaultSnowflake struct {
+	mu sync.Mutex
+	s  *snowflake
+}
+
+// nextSnowflake returns the next id of defaultSnowflake, drawing its node on
+// first use.
+func nextSnowflake() (any, error) {
+	defaultSnowflake.mu.Lock()
+	if defaultSnowflake.s == nil {
+		s, err := newSnowflake()
+		if err != nil {
+			defaultSnowflake.mu.Unlock()
+			return nil, err
+		}
+		defaultSnowflake.s = s
+	}
+	s := defaultSnowflake.s
+	defaultSnowflake.mu.Unlock()
+	return s.next(), nil
+}
+
+func newSnowflake() (*snowflake, error) {
+	var b [2]byte
+	if _, err := randRead(b[:]); err != nil {
+		return nil, err
+	}
+	return &snowflake{node: int64(binary.BigEndian.Uint16(b[:]) & 0x3ff)}, nil
+}
+
+func (s *snowflake) next() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UnixMilli() - snowflakeEpoch
+	if now < s.last {
+		now = s.last // clock went backwards
+	}
+	if now == s.last {
+		s.seq = (s.seq + 1) & 0xfff
+		if s.seq == 0 {
+			// sequence exhausted, wait for the next millisecond
+			for now <= s.last {
+				time.Sleep(100 * time.Microsecond)
+				now = time.Now().UnixMilli() - snowflakeEpoch
+			}
+		}
+	} else {
+		s.seq = 0
+	}
+	s.last = now
+	return now<<22 | s.node<<12 | s.seq
+}
@@ -0,0 +1,83 @@
+package oca
+
+import (
+	"crypto/rand"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinIDGenerators(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-([47])[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	v4, err := newUUIDv4()
+	assert.NoError(t, err)
+	assert.Equal(t, "4", uuid.FindStringSubmatch(v4)[1], v4)
+	v7, err := newUUIDv7()
+	assert.NoError(t, err)
+	assert.Equal(t, "7", uuid.FindStringSubmatch(v7)[1], v7)
+	other, err := newUUIDv4()
+	assert.NoError(t, err)
+	assert.NotEqual(t, v4, other)
+
+	ulid, err := newULID()
+	assert.NoError(t, err)
+	assert.Regexp(t, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`, ulid)
+
+	s, err := newSnowflake()
+	assert.NoError(t, err)
+	prev := s.next()
+	for i := 0; i < 10000; i++ {
+		id := s.next()
+		assert.Greater(t, id, prev)
+		prev = id
+	}
+}
+
+func TestGenerateIDs(t *testing.T) {
+	type Event struct {
+		ID   int64  `db:"id,pk" schema:"gen:snowflake"`
+		Ref  string `db:"ref" schema:"gen:ulid"`
+		Name string `db:"name"`
+	}
+
+	e := &Event{Ref: "keep"}
+	assert.NoError(t, generateIDs(e))
+	assert.NotZero(t, e.ID)
+	assert.Equal(t, "keep", e.Ref)
+
+	type Bad struct {
+		ID int64 `db:"id,pk" schema:"gen:uuidv4"`
+	}
+	assert.Error(t, generateIDs(&Bad{}))
+
+	type Unknown struct {
+		ID string `db:"id,pk" schema:"gen:nope"`
+	}
+	assert.Error(t, generateIDs(&Unknown{}))
+}
+
+func TestGenerateIDs_RandomSourceFails(t *testing.T) {
+	failure := errors.New("entropy unavailable")
+	randRead = func([]byte) (int, error) { return 0, failure }
+	defer func() { randRead = rand.Read }()
+
+	type Event struct {
+		ID string `db:"id,pk" schema:"gen:uuidv7"`
+	}
+	e := &Event{}
+	assert.ErrorIs(t, generateIDs(e), failure)
+	assert.Empty(t, e.ID)
+}
+
+func TestRegisterIDGeneratorE(t *testing.T) {
+	failure := errors.New("sequence exhausted")
+	RegisterIDGeneratorE("failing", func() (any, error) { return nil, failure })
+
+	type Event struct {
+		ID string `db:"id,pk" schema:"gen:failing"`
+	}
+	assert.ErrorIs(t, generateIDs(&Event{}), failure)
+}
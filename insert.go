@@ -2,40 +2,104 @@ package oca
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/mhdiiilham/oca/query"
 )
 
 // Insert inserts the entity into the database.
-// It automatically handles auto-increment fields and schema defaults (e.g., default:now()).
+// It automatically handles auto-increment fields and schema defaults (e.g., default:now(),
+// default:uuid()); zero fields with a default are filled by the database and, where the
+// dialect supports INSERT ... RETURNING, scanned back.
 // Multiple auto fields are supported and populated after insertion.
+// Fields tagged `schema:"autoCreateTime"` or `schema:"autoUpdateTime"` are
+// set from the repository clock and written back into entity, and empty
+// `schema:"gen:..."` fields are filled by their id generator. Fields tagged
+// `db:"...,readonly"` and the `schema:"soft_delete"` field are never
+// written and zero `db:"...,omitempty"` fields are left to the database,
+// then scanned back like defaults. The entity is validated against its
+// `schema` rules first (see Validate).
 func (r *Repository[T]) Insert(ctx context.Context, entity *T) error {
-	cols, args, autoFields, err := prepareInsertFields(entity)
+	if err := r.runHook(ctx, hookBeforeInsert, entity); err != nil {
+		return err
+	}
+	stamps, err := timestampFields(entity, "autoCreateTime", "autoUpdateTime")
 	if err != nil {
 		return err
 	}
+	if err := generateIDs(entity); err != nil {
+		return err
+	}
+	if err := Validate(entity); err != nil {
+		return err
+	}
+	now := r.now()
 
-	sqlStr, sqlArgs := buildInsertQuery(entity, cols, args, autoFields)
+	cols, args, autoFields, err := prepareInsertFields(entity, now, false)
+	if err != nil {
+		return err
+	}
 
-	if len(autoFields) > 0 {
-		return r.scanAutoFields(ctx, entity, sqlStr, sqlArgs, autoFields)
+	sqlStr, sqlArgs, err := buildInsertQuery(r.tableName(), cols, [][]any{args}, autoFields)
+	if err != nil {
+		return err
 	}
 
-	_, err = r.db.ExecContext(ctx, sqlStr, sqlArgs...)
-	return err
+	if len(autoFields) > 0 {
+		err = r.scanAutoFields(ctx, entity, sqlStr, sqlArgs, autoFields)
+	} else {
+		_, err = r.conn(ctx).ExecContext(ctx, sqlStr, sqlArgs...)
+	}
+	if err != nil {
+		return err
+	}
+	setTimestamps(stamps, now)
+	return r.runHook(ctx, hookAfterInsert, entity)
 }
 
-func prepareInsertFields[T any](entity *T) (cols []string, args []any, autoFields []FieldMeta, err error) {
+// prepareInsertFields returns the columns and values to insert for entity and
+// the fields to scan back: auto fields and, when the dialect supports
+// INSERT ... RETURNING, the fields filled from their schema default or from
+// the column default of a zero omitempty field.
+// Readonly and soft delete fields are never written. Zero omitempty fields
+// are skipped, or written as DEFAULT when batch is set so every row has the
+// same columns.
+func prepareInsertFields[T any](entity *T, now time.Time, batch bool) (cols []string, args []any, autoFields []FieldMeta, err error) {
+	d := query.GetDialect()
+	returning := query.Supports(d, query.FeatureInsertReturning)
 	fields := parseFields(entity)
 	for _, f := range fields {
 		switch {
 		case f.IsAuto:
 			autoFields = append(autoFields, f.FieldMeta)
-		case f.Schema["default"] == "now()":
+		case f.ReadOnly, f.hasSchema("soft_delete"):
+			continue
+		case f.hasSchema("autoCreateTime") || f.hasSchema("autoUpdateTime"):
 			cols = append(cols, f.Column)
-			args = append(args, query.Raw("NOW()"))
+			args = append(args, now)
+		case f.hasSchema("default"):
+			val := f.Value
+			if f.Zero {
+				if val, err = defaultExpr(d, f.Schema["default"]); err != nil {
+					return nil, nil, nil, err
+				}
+				if returning {
+					autoFields = append(autoFields, f.FieldMeta)
+				}
+			}
+			cols = append(cols, f.Column)
+			args = append(args, val)
+		case f.OmitEmpty && f.Zero:
+			if batch {
+				cols = append(cols, f.Column)
+				args = append(args, query.Raw("DEFAULT"))
+			}
+			if returning {
+				autoFields = append(autoFields, f.FieldMeta)
+			}
 		default:
 			cols = append(cols, f.Column)
 			args = append(args, f.Value)
@@ -44,11 +108,78 @@ func prepareInsertFields[T any](entity *T) (cols []string, args []any, autoField
 	return
 }
 
-func buildInsertQuery[T any](entity *T, cols []string, args []any, autoFields []FieldMeta) (string, []interface{}) {
-	var t T
-	builder := query.InsertInto(resolveTableName(t)).
-		Columns(cols...).
-		Values(args...)
+// InsertMany inserts entities with a single multi-row INSERT. Like Insert,
+// it fills id generators and timestamps, and scans auto fields back into
+// each entity through RETURNING. The order of the rows returned by a
+// multi-row INSERT is not guaranteed, so when fields must be scanned back the
+// entities are inserted one statement each, in a transaction unless the
+// repository already runs in one. Zero omitempty fields are written as
+// DEFAULT.
+//
+// Example:
+//
+//	err := repo.InsertMany(ctx, []*Event{{Name: "a"}, {Name: "b"}})
+func (r *Repository[T]) InsertMany(ctx context.Context, entities []*T) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	now := r.now()
+
+	var cols []string
+	var scan bool
+	rows := make([][]any, len(entities))
+	scanFields := make([][]FieldMeta, len(entities))
+	stamps := make([][]timestampField, len(entities))
+	for i, entity := range entities {
+		if entity == nil {
+			return fmt.Errorf("InsertMany: entity %d is nil", i)
+		}
+		if err := r.runHook(ctx, hookBeforeInsert, entity); err != nil {
+			return err
+		}
+		var err error
+		if stamps[i], err = timestampFields(entity, "autoCreateTime", "autoUpdateTime"); err != nil {
+			return err
+		}
+		if err := generateIDs(entity); err != nil {
+			return err
+		}
+		if err := Validate(entity); err != nil {
+			return fmt.Errorf("InsertMany: entity %d: %w", i, err)
+		}
+		if cols, rows[i], scanFields[i], err = prepareInsertFields(entity, now, true); err != nil {
+			return err
+		}
+		scan = scan || len(scanFields[i]) > 0
+	}
+
+	var err error
+	if scan {
+		err = r.insertEach(ctx, entities, cols, rows, scanFields)
+	} else {
+		var sqlStr string
+		var sqlArgs []any
+		if sqlStr, sqlArgs, err = buildInsertQuery(r.tableName(), cols, rows, nil); err == nil {
+			_, err = r.conn(ctx).ExecContext(ctx, sqlStr, sqlArgs...)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	for i, entity := range entities {
+		setTimestamps(stamps[i], now)
+		if err := r.runHook(ctx, hookAfterInsert, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildInsertQuery(table string, cols []string, rows [][]any, autoFields []FieldMeta) (string, []interface{}, error) {
+	builder := query.InsertInto(table).Columns(cols...)
+	for _, args := range rows {
+		builder.Values(args...)
+	}
 
 	if len(autoFields) > 0 {
 		autoCols := make([]string, len(autoFields))
@@ -58,7 +189,7 @@ func buildInsertQuery[T any](entity *T, cols []string, args []any, autoFields []
 		builder = builder.Returning(autoCols...)
 	}
 
-	return builder.ToSQL()
+	return builder.SQL()
 }
 
 func (r *Repository[T]) scanAutoFields(ctx context.Context, entity *T, sqlStr string, sqlArgs []interface{}, autoFields []FieldMeta) error {
@@ -66,7 +197,7 @@ func (r *Repository[T]) scanAutoFields(ctx context.Context, entity *T, sqlStr st
 		return fmt.Errorf("scanAutoFields: entity cannot be nil")
 	}
 
-	row := r.db.QueryRowContext(ctx, sqlStr, sqlArgs...)
+	row := r.conn(ctx).QueryRowContext(ctx, sqlStr, sqlArgs...)
 	val := reflect.ValueOf(entity)
 	targets, err := buildScanTargets(val, autoFields)
 	if err != nil {
@@ -75,3 +206,31 @@ func (r *Repository[T]) scanAutoFields(ctx context.Context, entity *T, sqlStr st
 
 	return row.Scan(targets...)
 }
+
+// insertEach inserts one row per statement and scans the fields listed for
+// each row back into its entity. Statements run in a transaction, the
+// repository's or the ambient one when there is one, so a failure leaves no
+// partial batch.
+func (r *Repository[T]) insertEach(ctx context.Context, entities []*T, cols []string, rows [][]any, scanFields [][]FieldMeta) error {
+	run := func(ctx context.Context) error {
+		for i, entity := range entities {
+			sqlStr, sqlArgs, err := buildInsertQuery(r.tableName(), cols, rows[i:i+1], scanFields[i])
+			if err != nil {
+				return err
+			}
+			if len(scanFields[i]) == 0 {
+				_, err = r.conn(ctx).ExecContext(ctx, sqlStr, sqlArgs...)
+			} else {
+				err = r.scanAutoFields(ctx, entity, sqlStr, sqlArgs, scanFields[i])
+			}
+			if err != nil {
+				return fmt.Errorf("InsertMany: entity %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+	if db, ok := r.db.(*sql.DB); ok && !r.inTransaction(ctx) {
+		return InTx(ctx, db, run)
+	}
+	return run(ctx)
+}
@@ -0,0 +1,76 @@
+package oca_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/stretchr/testify/assert"
+)
+
+type Tag struct {
+	ID   string `db:"id,pk" schema:"gen:fixed"`
+	Name string `db:"name"`
+}
+
+func TestRepository_InsertGeneratedID(t *testing.T) {
+	oca.RegisterIDGenerator("fixed", func() any { return "tag-1" })
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO tags \(id, name\) VALUES \(\?, \?\)`).
+		WithArgs("tag-1", "go").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO tags \(id, name\) VALUES \(\?, \?\), \(\?, \?\)`).
+		WithArgs("tag-1", "sql", "custom", "orm").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	repo := oca.NewRepository[Tag](db)
+	tag := &Tag{Name: "go"}
+	assert.NoError(t, repo.Insert(context.Background(), tag))
+	assert.Equal(t, "tag-1", tag.ID)
+
+	tags := []*Tag{{Name: "sql"}, {ID: "custom", Name: "orm"}}
+	assert.NoError(t, repo.InsertMany(context.Background(), tags))
+	assert.Equal(t, "tag-1", tags[0].ID)
+	assert.Equal(t, "custom", tags[1].ID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_InsertManyAutoFields(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// a multi-row RETURNING may come back in any order, so each row is
+	// inserted on its own
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO autousers \(name\) VALUES \(\?\) RETURNING id`).
+		WithArgs("Alice").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`INSERT INTO autousers \(name\) VALUES \(\?\) RETURNING id`).
+		WithArgs("Bob").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectCommit()
+
+	repo := oca.NewRepository[AutoUser](db)
+	users := []*AutoUser{{Name: "Alice"}, {Name: "Bob"}}
+	assert.NoError(t, repo.InsertMany(context.Background(), users))
+	assert.Equal(t, int64(1), users[0].ID)
+	assert.Equal(t, int64(2), users[1].ID)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO autousers \(name\) VALUES \(\?\) RETURNING id`).
+		WithArgs("Carol").
+		WillReturnError(errors.New("duplicate name"))
+	mock.ExpectRollback()
+	assert.ErrorContains(t, repo.InsertMany(context.Background(), []*AutoUser{{Name: "Carol"}}), "entity 0")
+
+	assert.NoError(t, repo.InsertMany(context.Background(), nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mhdiiilham/oca"
+	"github.com/mhdiiilham/oca/query"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -100,7 +101,8 @@ func TestRepository_Insert_DefaultNow(t *testing.T) {
 
 	repo := oca.NewRepository[TodoWithDefault](db)
 
-	mock.ExpectQuery(`INSERT INTO todowithdefaults \(title, created_at\) VALUES \(\?, NOW\(\)\) RETURNING id`).
+	// MySQL has no INSERT ... RETURNING for the defaulted column
+	mock.ExpectQuery(`INSERT INTO todowithdefaults \(title, created_at\) VALUES \(\?, CURRENT_TIMESTAMP\) RETURNING id$`).
 		WithArgs("Task 1").
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -108,10 +110,28 @@ func TestRepository_Insert_DefaultNow(t *testing.T) {
 	err := repo.Insert(context.Background(), todo)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), todo.ID)
+	assert.True(t, todo.CreatedAt.IsZero())
 	assert.Equal(t, "Task 1", todo.Title)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestRepository_Insert_DefaultWithoutReturning(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	type Note struct {
+		Title     string    `db:"title"`
+		CreatedAt time.Time `db:"created_at" schema:"default:now()"`
+	}
+
+	mock.ExpectExec(`INSERT INTO notes \(title, created_at\) VALUES \(\?, CURRENT_TIMESTAMP\)$`).
+		WithArgs("Hello").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, oca.NewRepository[Note](db).Insert(context.Background(), &Note{Title: "Hello"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRepository_Insert_MultipleAutoFields(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
@@ -150,7 +170,7 @@ func TestRepository_Insert_DefaultNow_WithAutoField(t *testing.T) {
 
 	repo := oca.NewRepository[Todo](db)
 
-	mock.ExpectQuery(`INSERT INTO todos \(title, created_at\) VALUES \(\?, NOW\(\)\) RETURNING id`).
+	mock.ExpectQuery(`INSERT INTO todos \(title, created_at\) VALUES \(\?, CURRENT_TIMESTAMP\) RETURNING id$`).
 		WithArgs("Task Default").
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(200))
 
@@ -161,3 +181,103 @@ func TestRepository_Insert_DefaultNow_WithAutoField(t *testing.T) {
 	assert.Equal(t, "Task Default", todo.Title)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestRepository_Insert_DefaultPerDialect(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	type Document struct {
+		ID     string `db:"id,pk" schema:"default:uuid()"`
+		Title  string `db:"title"`
+		Status string `db:"status" schema:"default:'draft'"`
+		Views  int    `db:"views" schema:"default:0"`
+	}
+	repo := oca.NewRepository[Document](db)
+
+	mock.ExpectQuery(`INSERT INTO documents \(id, title, status, views\) VALUES \(gen_random_uuid\(\), \$1, 'draft', 0\) RETURNING id, status, views`).
+		WithArgs("Intro").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "views"}).AddRow("0b5e6f3c-1a2b-4c3d-8e9f-0a1b2c3d4e5f", "draft", 0))
+
+	doc := &Document{Title: "Intro"}
+	assert.NoError(t, repo.Insert(context.Background(), doc))
+	assert.Equal(t, "0b5e6f3c-1a2b-4c3d-8e9f-0a1b2c3d4e5f", doc.ID)
+	assert.Equal(t, "draft", doc.Status)
+
+	// Set fields are bound instead of defaulted, and not scanned back.
+	mock.ExpectQuery(`INSERT INTO documents \(id, title, status, views\) VALUES \(\$1, \$2, \$3, 0\) RETURNING views$`).
+		WithArgs("doc-1", "Intro", "published").
+		WillReturnRows(sqlmock.NewRows([]string{"views"}).AddRow(0))
+
+	doc = &Document{ID: "doc-1", Title: "Intro", Status: "published"}
+	assert.NoError(t, repo.Insert(context.Background(), doc))
+	assert.Equal(t, "published", doc.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	type Bad struct {
+		Name string `db:"name" schema:"default:random()"`
+	}
+	err := oca.NewRepository[Bad](db).Insert(context.Background(), &Bad{})
+	assert.Error(t, err)
+}
+
+type Invoice struct {
+	ID       int64  `db:"id,pk,auto"`
+	Number   string `db:"number,insertonly"`
+	Currency string `db:"currency,omitempty"`
+	Total    int64  `db:"total,readonly"`
+}
+
+func TestRepository_Insert_WriteOptions(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := oca.NewRepository[Invoice](db)
+
+	mock.ExpectQuery(`INSERT INTO invoices \(number\) VALUES \(\?\) RETURNING id`).
+		WithArgs("INV-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`INSERT INTO invoices \(number, currency\) VALUES \(\?, \?\) RETURNING id`).
+		WithArgs("INV-2", "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO invoices \(number, currency\) VALUES \(\?, DEFAULT\) RETURNING id`).
+		WithArgs("INV-3").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery(`INSERT INTO invoices \(number, currency\) VALUES \(\?, \?\) RETURNING id`).
+		WithArgs("INV-4", "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectCommit()
+
+	ctx := context.Background()
+	assert.NoError(t, repo.Insert(ctx, &Invoice{Number: "INV-1", Total: 100}))
+	assert.NoError(t, repo.Insert(ctx, &Invoice{Number: "INV-2", Currency: "EUR"}))
+	assert.NoError(t, repo.InsertMany(ctx, []*Invoice{{Number: "INV-3"}, {Number: "INV-4", Currency: "EUR"}}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Insert_OmitEmptyScannedBack(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := oca.NewRepository[Invoice](db)
+
+	mock.ExpectQuery(`INSERT INTO invoices \(number\) VALUES \(\$1\) RETURNING id, currency`).
+		WithArgs("INV-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow(1, "USD"))
+	mock.ExpectQuery(`INSERT INTO invoices \(number, currency\) VALUES \(\$1, \$2\) RETURNING id$`).
+		WithArgs("INV-2", "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	ctx := context.Background()
+	inv := &Invoice{Number: "INV-1"}
+	assert.NoError(t, repo.Insert(ctx, inv))
+	assert.Equal(t, "USD", inv.Currency)
+	assert.NoError(t, repo.Insert(ctx, &Invoice{Number: "INV-2", Currency: "EUR"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
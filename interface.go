@@ -43,3 +43,116 @@ type GenericStore[T any] interface {
 	//	todo, err := repo.FindOne(ctx, oca.Where(query.C("id").Eq(28)))
 	FindOne(ctx context.Context, opts ...FilterOptions) (*T, error)
 }
+
+// BatchInserter inserts several records at once.
+type BatchInserter[T any] interface {
+	// InsertMany inserts entities, populating auto fields, generated ids and
+	// timestamps the same way as Insert. It uses a single multi-row INSERT
+	// unless fields must be scanned back, in which case it inserts one row
+	// per statement in a transaction.
+	//
+	// Example:
+	//
+	//	err := repo.InsertMany(ctx, []*User{{Name: "Alice"}, {Name: "Bob"}})
+	InsertMany(ctx context.Context, entities []*T) error
+}
+
+// Updater updates existing records.
+type Updater[T any] interface {
+	// Update writes every column of entity to the record identified by its
+	// primary key. Fields tagged `schema:"autoUpdateTime"` are set to the
+	// current time and written back into entity.
+	//
+	// Example:
+	//
+	//	todo.Title = "Renamed"
+	//	err := repo.Update(ctx, &todo)
+	Update(ctx context.Context, entity *T) error
+
+	// Upsert inserts entity or, when a record with the same primary key
+	// exists, updates it. autoCreateTime fields keep their stored value.
+	//
+	// Example:
+	//
+	//	err := repo.Upsert(ctx, &Setting{Key: "theme", Value: "dark"})
+	Upsert(ctx context.Context, entity *T) error
+}
+
+// KeyFinder looks records up by primary key.
+type KeyFinder[T any] interface {
+	// FindByPK retrieves the record whose primary key equals keys, given in
+	// the order of the `pk` fields. Composite keys take one value per column.
+	// Returns sql.ErrNoRows if no record is found.
+	//
+	// Example:
+	//
+	//	todo, err := repo.FindByPK(ctx, 28)
+	FindByPK(ctx context.Context, keys ...any) (*T, error)
+
+	// FindByPKs retrieves the records whose primary keys are listed in keys.
+	//
+	// Example:
+	//
+	//	todos, err := repo.FindByPKs(ctx, [][]any{{1}, {2}})
+	FindByPKs(ctx context.Context, keys [][]any) ([]T, error)
+
+	// Reload refreshes entity in place from the database using its primary key.
+	Reload(ctx context.Context, entity *T) error
+}
+
+// Counter counts records.
+type Counter[T any] interface {
+	// Count returns the number of records matching the provided filters.
+	//
+	// Example:
+	//
+	//	n, err := repo.Count(ctx, oca.Where(query.C("done").Eq(false)))
+	Count(ctx context.Context, opts ...FilterOptions) (int64, error)
+}
+
+// Deleter removes records.
+type Deleter[T any] interface {
+	// Delete removes the record identified by the primary key of entity.
+	// Models with a `schema:"soft_delete"` field are soft-deleted instead:
+	// the field is set to the current time and the row is hidden from
+	// Finds, FindOne and Count unless WithTrashed or OnlyTrashed is used.
+	//
+	// Example:
+	//
+	//	err := repo.Delete(ctx, &todo)
+	Delete(ctx context.Context, entity *T) error
+
+	// DeleteReturning removes the records matching the provided filters and
+	// returns them. The active dialect must support DELETE ... RETURNING.
+	// Soft-deleted models are returned with their soft delete field set.
+	//
+	// Example:
+	//
+	//	removed, err := repo.DeleteReturning(ctx, oca.Where(query.C("expires_at").Lt(now)))
+	DeleteReturning(ctx context.Context, opts ...FilterOptions) ([]T, error)
+}
+
+// SoftDeleteStore manages soft-deleted records.
+type SoftDeleteStore[T any] interface {
+	// ForceDelete removes the record identified by the primary key of entity,
+	// bypassing soft delete.
+	ForceDelete(ctx context.Context, entity *T) error
+
+	// Restore undoes a soft delete of the record identified by the primary
+	// key of entity.
+	Restore(ctx context.Context, entity *T) error
+}
+
+// Store combines GenericStore with every optional capability. It is what
+// NewRepository and NewTxRepository return.
+type Store[T any] interface {
+	GenericStore[T]
+	BatchInserter[T]
+	Updater[T]
+	KeyFinder[T]
+	Counter[T]
+	Deleter[T]
+	SoftDeleteStore[T]
+}
+
+var _ Store[struct{}] = (*Repository[struct{}])(nil)
@@ -0,0 +1,99 @@
+package oca
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/mhdiiilham/oca/query"
+)
+
+// KeyedStore extends Store with lookups typed by the primary key, so
+// a key of the wrong type is a compile error rather than a database error.
+type KeyedStore[T any, ID comparable] interface {
+	Store[T]
+
+	// Get retrieves the record with primary key id.
+	// Returns sql.ErrNoRows if no record is found.
+	//
+	// Example:
+	//
+	//	user, err := users.Get(ctx, 42)
+	Get(ctx context.Context, id ID) (*T, error)
+
+	// GetMany retrieves the records with the given primary keys, keyed by id.
+	// Ids without a record are absent from the map.
+	GetMany(ctx context.Context, ids []ID) (map[ID]T, error)
+
+	// DeleteByID deletes the record with primary key id, honouring soft delete.
+	DeleteByID(ctx context.Context, id ID) error
+}
+
+// KeyedRepository is a Repository whose primary key has type ID.
+type KeyedRepository[T any, ID comparable] struct {
+	*Repository[T]
+	pk FieldMeta
+}
+
+// NewKeyedRepository returns a repository for T keyed by ID. It returns an
+// error unless T has exactly one `pk` field and that field can hold an ID.
+//
+// Example:
+//
+//	users, err := oca.NewKeyedRepository[User, int64](db)
+//	user, err := users.Get(ctx, 42)
+func NewKeyedRepository[T any, ID comparable](db *sql.DB, opts ...RepositoryOption) (KeyedStore[T, ID], error) {
+	pks, err := primaryKeyFields[T]()
+	if err != nil {
+		return nil, err
+	}
+	if len(pks) != 1 {
+		return nil, fmt.Errorf("%w: keyed repository needs exactly one key column, %T has %d", ErrInvalidKey, *new(T), len(pks))
+	}
+	idType := reflect.TypeOf((*ID)(nil)).Elem()
+	if !idType.AssignableTo(pks[0].Type) || !pks[0].Type.ConvertibleTo(idType) {
+		return nil, fmt.Errorf("%w: %s is %s, not assignable from %s", ErrInvalidKey, pks[0].Column, pks[0].Type, idType)
+	}
+	return &KeyedRepository[T, ID]{
+		Repository: newRepository[T](db, false, opts),
+		pk:         pks[0],
+	}, nil
+}
+
+// Get retrieves the record with primary key id.
+func (r *KeyedRepository[T, ID]) Get(ctx context.Context, id ID) (*T, error) {
+	return r.FindOne(ctx, Where(query.C(r.pk.Column).Eq(id)))
+}
+
+// GetMany retrieves the records with the given primary keys, keyed by id.
+func (r *KeyedRepository[T, ID]) GetMany(ctx context.Context, ids []ID) (map[ID]T, error) {
+	result := make(map[ID]T, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	vals := make([]any, len(ids))
+	for i, id := range ids {
+		vals[i] = id
+	}
+	rows, err := r.Finds(ctx, Where(query.C(r.pk.Column).In(vals...)))
+	if err != nil {
+		return nil, err
+	}
+
+	idType := reflect.TypeOf((*ID)(nil)).Elem()
+	for _, row := range rows {
+		key := reflect.ValueOf(&row).Elem().FieldByIndex(r.pk.index()).Convert(idType).Interface().(ID)
+		result[key] = row
+	}
+	return result, nil
+}
+
+// DeleteByID deletes the record with primary key id. Soft-deleted models are
+// soft-deleted, as with Delete.
+func (r *KeyedRepository[T, ID]) DeleteByID(ctx context.Context, id ID) error {
+	var entity T
+	reflect.ValueOf(&entity).Elem().FieldByIndex(r.pk.index()).Set(reflect.ValueOf(id))
+	return r.Delete(ctx, &entity)
+}
@@ -0,0 +1,74 @@
+package oca_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedRepository(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	users, err := oca.NewKeyedRepository[AutoUser, int64](db)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	mock.ExpectQuery(`SELECT id, name FROM autousers WHERE id = \? LIMIT \?`).
+		WithArgs(int64(1), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Alice"))
+	user, err := users.Get(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", user.Name)
+
+	mock.ExpectQuery(`SELECT id, name FROM autousers WHERE id IN \(\?,\?,\?\)`).
+		WithArgs(int64(1), int64(2), int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Alice").AddRow(3, "Carol"))
+	found, err := users.GetMany(ctx, []int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]AutoUser{1: {ID: 1, Name: "Alice"}, 3: {ID: 3, Name: "Carol"}}, found)
+
+	mock.ExpectExec(`DELETE FROM autousers WHERE id = \?`).
+		WithArgs(int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, users.DeleteByID(ctx, 2))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestKeyedRepository_SoftDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	posts, err := oca.NewKeyedRepository[Post, int64](db)
+	assert.NoError(t, err)
+
+	mock.ExpectExec(`UPDATE posts SET deleted_at = \? WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(sqlmock.AnyArg(), int64(8)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, posts.DeleteByID(context.Background(), 8))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewKeyedRepository_Validation(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = oca.NewKeyedRepository[AutoUser, int32](db)
+	assert.ErrorIs(t, err, oca.ErrInvalidKey)
+
+	_, err = oca.NewKeyedRepository[AutoUser, string](db)
+	assert.ErrorIs(t, err, oca.ErrInvalidKey)
+
+	_, err = oca.NewKeyedRepository[Membership, int64](db)
+	assert.ErrorIs(t, err, oca.ErrInvalidKey)
+
+	_, err = oca.NewKeyedRepository[SimpleUser, int64](db)
+	assert.ErrorIs(t, err, oca.ErrNoPrimaryKey)
+}
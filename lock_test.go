@@ -0,0 +1,115 @@
+package oca_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_FindsForUpdateInTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, title, created_at FROM todos WHERE id = \? LIMIT \? FOR UPDATE SKIP LOCKED`).
+		WithArgs(1, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "created_at"}).AddRow(1, "Task 1", now))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	repo := oca.NewTxRepository[Todo](tx)
+	todos, err := repo.Finds(context.Background(),
+		oca.Where(query.C("id").Eq(1)),
+		oca.Limit(5),
+		oca.ForUpdate(),
+		oca.SkipLocked(),
+	)
+	assert.NoError(t, err)
+	assert.Len(t, todos, 1)
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_LockOutsideTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Todo](db)
+	_, err = repo.Finds(context.Background(), oca.ForUpdate())
+	assert.ErrorIs(t, err, oca.ErrLockOutsideTx)
+
+	_, err = oca.Select[Todo](context.Background(), db, query.From("todos").ForShare())
+	assert.ErrorIs(t, err, oca.ErrLockOutsideTx)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// instrumentedTx wraps a transaction the way tracing libraries do.
+type instrumentedTx struct {
+	oca.DBTX
+}
+
+func (instrumentedTx) InTransaction() bool { return true }
+
+func TestSelect_LockInTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	b := query.From("todos").Select("id", "title", "created_at").ForUpdate()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, title, created_at FROM todos FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "created_at"}))
+	mock.ExpectQuery(`SELECT id, title, created_at FROM todos FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "created_at"}))
+	mock.ExpectCommit()
+
+	err = oca.InTx(context.Background(), db, func(ctx context.Context) error {
+		if _, err := oca.Select[Todo](ctx, db, b); err != nil {
+			return err
+		}
+		tx, _ := oca.TxFromContext(ctx)
+		_, err := oca.Select[Todo](ctx, instrumentedTx{tx}, b)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelect_LockOnConnRejected(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	conn, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	// a bare connection runs in autocommit mode
+	b := query.From("todos").Select("id", "title", "created_at").ForUpdate()
+	_, err = oca.Select[Todo](context.Background(), conn, b)
+	assert.ErrorIs(t, err, oca.ErrLockOutsideTx)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_ConflictingLocks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	_, err = oca.NewTxRepository[Todo](tx).Finds(context.Background(), oca.ForUpdate(), oca.ForShare())
+	assert.ErrorIs(t, err, query.ErrConflictingClause)
+}
@@ -8,12 +8,17 @@ import (
 
 // FieldMeta holds metadata about a struct field for ORM mapping.
 type FieldMeta struct {
-	Name      string            // Go struct field name
-	Column    string            // DB column name from `db` tag
-	Index     int               // field index in struct
-	IsPrimary bool              // true if marked as primary key
-	IsAuto    bool              // true if marked as auto increment
-	Schema    map[string]string // parsed key:value pairs from `schema` tag
+	Name       string            // Go struct field name
+	Column     string            // DB column name from `db` tag
+	Index      int               // field index in struct (the top-level field for promoted fields)
+	IndexPath  []int             // full index path, for reflect.Value.FieldByIndex
+	Type       reflect.Type      // Go type of the field
+	IsPrimary  bool              // true if marked as primary key
+	IsAuto     bool              // true if marked as auto increment
+	OmitEmpty  bool              // true if marked omitempty: zero values are not written
+	ReadOnly   bool              // true if marked readonly: selected but never written
+	InsertOnly bool              // true if marked insertonly: written on insert only
+	Schema     map[string]string // parsed key:value pairs from `schema` tag; bare flags map to ""
 }
 
 var (
@@ -38,46 +43,157 @@ func getStructMeta(t reflect.Type) []FieldMeta {
 	}
 
 	// build metadata
-	var fields []FieldMeta
+	fields := promoteFields(structFields(nil, t, "", nil))
+
+	// store canonical slice in cache
+	fieldCache.Store(t, fields)
+
+	// return a copy to callers
+	out := make([]FieldMeta, len(fields))
+	copy(out, fields)
+	return out
+}
+
+// structFields appends the db-tagged fields of t to fields. Anonymous struct
+// fields without a tag are promoted, and fields tagged `db:"prefix,inline"`
+// are flattened with prefix prepended to their columns. Embedded pointers
+// (*Base) are not promoted: a nil pointer has no fields to read or scan
+// into, so embed the struct by value instead. Duplicate columns are resolved
+// by promoteFields.
+func structFields(fields []FieldMeta, t reflect.Type, prefix string, index []int) []FieldMeta {
 	for i := 0; i < t.NumField(); i++ {
 		sf := t.Field(i)
+		path := append(append([]int(nil), index...), i)
 		tag := sf.Tag.Get("db")
-		if tag == "" || tag == "-" {
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				fields = structFields(fields, sf.Type, prefix, path)
+			}
 			continue
 		}
 
 		parts := strings.Split(tag, ",")
 		meta := FieldMeta{
-			Name:   sf.Name,
-			Column: parts[0],
-			Index:  i,
+			Name:      sf.Name,
+			Column:    prefix + parts[0],
+			Index:     path[0],
+			IndexPath: path,
+			Type:      sf.Type,
+			Schema:    parseSchemaTag(sf.Tag.Get("schema")),
 		}
 
+		inline := false
 		for _, opt := range parts[1:] {
 			switch strings.TrimSpace(opt) {
 			case "pk":
 				meta.IsPrimary = true
 			case "auto":
 				meta.IsAuto = true
+			case "omitempty":
+				meta.OmitEmpty = true
+			case "readonly":
+				meta.ReadOnly = true
+			case "insertonly":
+				meta.InsertOnly = true
+			case "inline":
+				inline = true
 			}
 		}
+		if inline && sf.Type.Kind() == reflect.Struct {
+			fields = structFields(fields, sf.Type, meta.Column, path)
+			continue
+		}
 		fields = append(fields, meta)
 	}
+	return fields
+}
 
-	// store canonical slice in cache
-	fieldCache.Store(t, fields)
+// promoteFields resolves columns mapped by several fields of t, as in Go
+// field promotion: a field shadows deeper ones with the same column, and a
+// column mapped by several embedded fields at the same depth is ambiguous and
+// dropped, like an ambiguous selector in Go or encoding/json. Top-level
+// fields are always kept.
+func promoteFields(all []FieldMeta) []FieldMeta {
+	depth := map[string]int{}
+	count := map[string]int{}
+	for _, f := range all {
+		d, seen := depth[f.Column]
+		switch {
+		case !seen || len(f.IndexPath) < d:
+			depth[f.Column], count[f.Column] = len(f.IndexPath), 1
+		case len(f.IndexPath) == d:
+			count[f.Column]++
+		}
+	}
 
-	// return a copy to callers
-	out := make([]FieldMeta, len(fields))
-	copy(out, fields)
-	return out
+	var fields []FieldMeta
+	done := map[string]bool{}
+	for _, f := range all {
+		col := f.Column
+		if done[col] {
+			continue
+		}
+		done[col] = true
+		if depth[col] > 1 && count[col] > 1 {
+			continue
+		}
+		// emit the winners where the column first appears
+		for _, g := range all {
+			if g.Column == col && len(g.IndexPath) == depth[col] {
+				fields = append(fields, g)
+			}
+		}
+	}
+	return fields
+}
+
+// index returns the index path of the field, falling back to Index for
+// metadata built without one.
+func (m FieldMeta) index() []int {
+	if m.IndexPath != nil {
+		return m.IndexPath
+	}
+	return []int{m.Index}
+}
+
+// parseSchemaTag parses a `schema` tag such as "default:now(),soft_delete".
+// Options without a value (flags) are stored with an empty value.
+func parseSchemaTag(tag string) map[string]string {
+	schema := map[string]string{}
+	if tag == "" {
+		return schema
+	}
+	for _, pair := range strings.Split(tag, ",") {
+		kv := strings.SplitN(pair, ":", 2)
+		key := strings.TrimSpace(kv[0])
+		if key == "" {
+			continue
+		}
+		if len(kv) == 2 {
+			schema[key] = strings.TrimSpace(kv[1])
+		} else {
+			schema[key] = ""
+		}
+	}
+	return schema
 }
 
-// parseFields extracts field metadata and current values.
+// hasSchema reports whether the field's `schema` tag contains option key.
+func (m FieldMeta) hasSchema(key string) bool {
+	_, ok := m.Schema[key]
+	return ok
+}
+
+// parseFields extracts field metadata and current values. Zero reports
+// whether the field holds its zero value.
 // This function is cheap after first call thanks to caching.
 func parseFields(entity any) []struct {
 	FieldMeta
 	Value interface{}
+	Zero  bool
 } {
 	val := reflect.ValueOf(entity)
 	if val.Kind() == reflect.Ptr {
@@ -88,30 +204,19 @@ func parseFields(entity any) []struct {
 	result := make([]struct {
 		FieldMeta
 		Value interface{}
+		Zero  bool
 	}, len(metas))
 
 	for i, m := range metas {
-		// Parse schema tag
-		schemaTag := val.Type().Field(m.Index).Tag.Get("schema")
-		schema := map[string]string{}
-		if schemaTag != "" {
-			pairs := strings.Split(schemaTag, ",")
-			for _, pair := range pairs {
-				kv := strings.SplitN(pair, ":", 2)
-				if len(kv) == 2 {
-					schema[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
-				}
-			}
-		}
-
-		m.Schema = schema
-
+		field := val.FieldByIndex(m.index())
 		result[i] = struct {
 			FieldMeta
 			Value interface{}
+			Zero  bool
 		}{
 			FieldMeta: m,
-			Value:     val.Field(m.Index).Interface(),
+			Value:     field.Interface(),
+			Zero:      field.IsZero(),
 		}
 	}
 
@@ -80,3 +80,96 @@ func TestCacheBehaviorWithSchema(t *testing.T) {
 	assert.Equal(t, metas1, metas2, "Cached metadata should be identical")
 	assert.True(t, &metas1[0] != &metas2[0], "Should be different slices but equal content")
 }
+
+func TestParseSchemaTagFlags(t *testing.T) {
+	assert.Equal(t, map[string]string{"soft_delete": "", "default": "now()"}, parseSchemaTag("soft_delete, default:now()"))
+	assert.Empty(t, parseSchemaTag(""))
+}
+
+func TestGetStructMetaWriteOptions(t *testing.T) {
+	type Row struct {
+		Nickname string `db:"nickname,omitempty"`
+		Total    int    `db:"total,readonly"`
+		Owner    string `db:"owner, insertonly"`
+	}
+	metas := getStructMeta(reflect.TypeOf(Row{}))
+	assert.True(t, metas[0].OmitEmpty)
+	assert.True(t, metas[1].ReadOnly)
+	assert.True(t, metas[2].InsertOnly)
+	assert.False(t, metas[0].ReadOnly || metas[0].InsertOnly)
+}
+
+func TestGetStructMetaEmbedded(t *testing.T) {
+	type Base struct {
+		ID        int64     `db:"id,pk,auto"`
+		CreatedAt time.Time `db:"created_at" schema:"autoCreateTime"`
+	}
+	type Address struct {
+		City string `db:"city"`
+		Zip  string `db:"zip"`
+	}
+	type Customer struct {
+		Base
+		Name      string    `db:"name"`
+		Address   Address   `db:"addr_,inline"`
+		CreatedAt time.Time `db:"created_at"` // shadows Base.CreatedAt
+	}
+
+	metas := getStructMeta(reflect.TypeOf(Customer{}))
+	var cols []string
+	for _, m := range metas {
+		cols = append(cols, m.Column)
+	}
+	assert.Equal(t, []string{"id", "created_at", "name", "addr_city", "addr_zip"}, cols)
+	assert.Equal(t, []int{0, 0}, metas[0].IndexPath)
+	assert.True(t, metas[0].IsPrimary)
+	assert.Equal(t, []int{3}, metas[1].IndexPath)
+	assert.Empty(t, metas[1].Schema)
+	assert.Equal(t, []int{2, 1}, metas[4].IndexPath)
+	assert.Equal(t, 2, metas[4].Index)
+}
+
+func TestGetStructMetaAmbiguous(t *testing.T) {
+	type Audit struct {
+		Note string `db:"note"`
+	}
+	type Review struct {
+		Note string `db:"note"`
+	}
+	type Both struct {
+		Audit
+		Review
+	}
+	// neither embedded field wins, so the column is dropped as in Go
+	assert.Empty(t, getStructMeta(reflect.TypeOf(Both{})))
+
+	// a shallower field resolves the conflict, as in Go
+	type Resolved struct {
+		Audit
+		Review
+		Note string `db:"note"`
+	}
+	metas := getStructMeta(reflect.TypeOf(Resolved{}))
+	assert.Len(t, metas, 1)
+	assert.Equal(t, []int{2}, metas[0].IndexPath)
+
+	// embedded pointers are not promoted
+	type Pointer struct {
+		*Audit
+		ID int64 `db:"id"`
+	}
+	metas = getStructMeta(reflect.TypeOf(Pointer{}))
+	assert.Len(t, metas, 1)
+	assert.Equal(t, "id", metas[0].Column)
+
+	// top-level fields are kept as declared, even with the same column
+	type Twice struct {
+		Audit
+		A string `db:"note"`
+		B string `db:"note"`
+	}
+	metas = getStructMeta(reflect.TypeOf(Twice{}))
+	assert.Len(t, metas, 2)
+	assert.Equal(t, []int{1}, metas[0].IndexPath)
+	assert.Equal(t, []int{2}, metas[1].IndexPath)
+}
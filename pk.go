@@ -0,0 +1,209 @@
+package oca
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/mhdiiilham/oca/query"
+)
+
+// FindByPK retrieves the record whose primary key equals keys, given in the
+// order of the `pk` fields of T. Returns sql.ErrNoRows if nothing is found
+// and ErrInvalidKey if the key count or types do not match.
+//
+// Example:
+//
+//	user, err := repo.FindByPK(ctx, 42)
+//	member, err := members.FindByPK(ctx, orgID, userID) // composite key
+func (r *Repository[T]) FindByPK(ctx context.Context, keys ...any) (*T, error) {
+	where, err := keyCondition[T](keys)
+	if err != nil {
+		return nil, err
+	}
+	return r.FindOne(ctx, Where(where))
+}
+
+// FindByPKs retrieves the records whose primary keys are listed in keys,
+// one []any per record. Keys without a matching record are skipped and the
+// order of the results is not guaranteed.
+//
+// Example:
+//
+//	users, err := repo.FindByPKs(ctx, [][]any{{1}, {2}, {3}})
+func (r *Repository[T]) FindByPKs(ctx context.Context, keys [][]any) ([]T, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	pks, err := primaryKeyFields[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	var where query.Condition
+	if len(pks) == 1 {
+		var vals []any
+		for _, key := range keys {
+			if vals, err = appendKeyValues(vals, pks, key); err != nil {
+				return nil, err
+			}
+		}
+		where = query.C(pks[0].Column).In(vals...)
+	} else {
+		conds := make([]query.Condition, len(keys))
+		for i, key := range keys {
+			if conds[i], err = keyCondition[T](key); err != nil {
+				return nil, err
+			}
+		}
+		where = query.Or(conds...)
+	}
+	return r.Finds(ctx, Where(where))
+}
+
+// Reload refreshes entity in place from the record with the same primary
+// key, including soft-deleted records. Returns sql.ErrNoRows if the record
+// no longer exists.
+func (r *Repository[T]) Reload(ctx context.Context, entity *T) error {
+	where, err := primaryKeyCondition(entity)
+	if err != nil {
+		return err
+	}
+	results, err := r.Finds(ctx, Where(where), WithTrashed(), Limit(1))
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return sql.ErrNoRows
+	}
+	*entity = results[0]
+	return nil
+}
+
+// primaryKeyFields returns the `pk` fields of T in declaration order.
+func primaryKeyFields[T any]() ([]FieldMeta, error) {
+	var entity T
+	var pks []FieldMeta
+	for _, m := range getStructMeta(reflect.TypeOf(entity)) {
+		if m.IsPrimary {
+			pks = append(pks, m)
+		}
+	}
+	if len(pks) == 0 {
+		return nil, ErrNoPrimaryKey
+	}
+	return pks, nil
+}
+
+// keyCondition returns "pk = ?" for every primary key field of T bound to
+// keys, joined with AND for composite keys.
+func keyCondition[T any](keys []any) (query.Condition, error) {
+	pks, err := primaryKeyFields[T]()
+	if err != nil {
+		return query.Condition{}, err
+	}
+	vals, err := appendKeyValues(nil, pks, keys)
+	if err != nil {
+		return query.Condition{}, err
+	}
+	if len(pks) == 1 {
+		return query.C(pks[0].Column).Eq(vals[0]), nil
+	}
+	conds := make([]query.Condition, len(pks))
+	for i, pk := range pks {
+		conds[i] = query.C(pk.Column).Eq(vals[i])
+	}
+	return query.And(conds...), nil
+}
+
+// appendKeyValues checks keys against the primary key fields and appends
+// them to dst, converting numeric keys to the field type (an untyped 42 for
+// an int64 key, for example).
+func appendKeyValues(dst []any, pks []FieldMeta, keys []any) ([]any, error) {
+	if len(keys) != len(pks) {
+		return nil, fmt.Errorf("%w: got %d values for %d key columns", ErrInvalidKey, len(keys), len(pks))
+	}
+	for i, key := range keys {
+		val, err := convertKey(pks[i], key)
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, val)
+	}
+	return dst, nil
+}
+
+// convertKey returns key as a value of the field's type.
+func convertKey(pk FieldMeta, key any) (any, error) {
+	if key == nil {
+		return nil, fmt.Errorf("%w: nil value for %s", ErrInvalidKey, pk.Column)
+	}
+	kv := reflect.ValueOf(key)
+	switch {
+	case kv.Type().AssignableTo(pk.Type):
+		return key, nil
+	case isNumber(kv.Kind()) && isNumber(pk.Type.Kind()):
+		if !convertsExactly(kv, pk.Type) {
+			return nil, fmt.Errorf("%w: %v does not fit %s (%s)", ErrInvalidKey, key, pk.Column, pk.Type)
+		}
+		return kv.Convert(pk.Type).Interface(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s is %s, got %T", ErrInvalidKey, pk.Column, pk.Type, key)
+	}
+}
+
+// convertsExactly reports whether the number kv keeps its value when
+// converted to t: a float with a fraction, a value out of range and a
+// negative value for an unsigned type do not. Integers converted to a float
+// type must be within its exact integer range (2^53 for float64).
+func convertsExactly(kv reflect.Value, t reflect.Type) bool {
+	to := reflect.New(t).Elem()
+	switch {
+	case kv.CanInt():
+		i := kv.Int()
+		switch {
+		case to.CanInt():
+			return !to.OverflowInt(i)
+		case to.CanUint():
+			return i >= 0 && !to.OverflowUint(uint64(i))
+		default:
+			return i >= -exactFloatInt(t) && i <= exactFloatInt(t)
+		}
+	case kv.CanUint():
+		u := kv.Uint()
+		switch {
+		case to.CanInt():
+			return u <= math.MaxInt64 && !to.OverflowInt(int64(u))
+		case to.CanUint():
+			return !to.OverflowUint(u)
+		default:
+			return u <= uint64(exactFloatInt(t))
+		}
+	default:
+		f := kv.Float()
+		switch {
+		case to.CanInt():
+			return f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !to.OverflowInt(int64(f))
+		case to.CanUint():
+			return f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !to.OverflowUint(uint64(f))
+		default:
+			return !to.OverflowFloat(f)
+		}
+	}
+}
+
+// exactFloatInt returns the largest integer below which every integer is
+// exactly representable by the float type t.
+func exactFloatInt(t reflect.Type) int64 {
+	if t.Bits() == 32 {
+		return 1 << 24
+	}
+	return 1 << 53
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
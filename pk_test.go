@@ -0,0 +1,115 @@
+package oca_test
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/stretchr/testify/assert"
+)
+
+type Membership struct {
+	OrgID  int64  `db:"org_id,pk"`
+	UserID int64  `db:"user_id,pk"`
+	Role   string `db:"role"`
+}
+
+func TestRepository_FindByPK(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[AutoUser](db)
+
+	mock.ExpectQuery(`SELECT id, name FROM autousers WHERE id = \? LIMIT \?`).
+		WithArgs(int64(42), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(42, "Alice"))
+
+	user, err := repo.FindByPK(context.Background(), 42)
+	assert.NoError(t, err)
+	assert.Equal(t, &AutoUser{ID: 42, Name: "Alice"}, user)
+
+	_, err = repo.FindByPK(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, oca.ErrInvalidKey)
+	_, err = repo.FindByPK(context.Background(), "42")
+	assert.ErrorIs(t, err, oca.ErrInvalidKey)
+	for _, lossy := range []any{1.5, math.NaN(), math.Inf(1), 1e19, uint64(math.MaxUint64)} {
+		_, err = repo.FindByPK(context.Background(), lossy)
+		assert.ErrorIs(t, err, oca.ErrInvalidKey, "%v", lossy)
+	}
+	_, err = oca.NewRepository[SimpleUser](db).FindByPK(context.Background(), 1)
+	assert.ErrorIs(t, err, oca.ErrNoPrimaryKey)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_FindByPKComposite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Membership](db)
+	cols := []string{"org_id", "user_id", "role"}
+
+	mock.ExpectQuery(`SELECT org_id, user_id, role FROM memberships WHERE \(org_id = \? AND user_id = \?\) LIMIT \?`).
+		WithArgs(int64(1), int64(2), 1).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(1, 2, "admin"))
+	m, err := repo.FindByPK(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", m.Role)
+
+	mock.ExpectQuery(`SELECT org_id, user_id, role FROM memberships WHERE \(\(org_id = \? AND user_id = \?\) OR \(org_id = \? AND user_id = \?\)\)`).
+		WithArgs(int64(1), int64(2), int64(1), int64(3)).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(1, 2, "admin").AddRow(1, 3, "viewer"))
+	ms, err := repo.FindByPKs(context.Background(), [][]any{{1, 2}, {1, 3}})
+	assert.NoError(t, err)
+	assert.Len(t, ms, 2)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_FindByPKs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[AutoUser](db)
+
+	mock.ExpectQuery(`SELECT id, name FROM autousers WHERE id IN \(\?,\?\)`).
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "A").AddRow(2, "B"))
+	users, err := repo.FindByPKs(context.Background(), [][]any{{1}, {int64(2)}})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	users, err = repo.FindByPKs(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Reload(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Post](db)
+	post := &Post{ID: 5, Title: "stale"}
+
+	mock.ExpectQuery(`SELECT id, title, deleted_at FROM posts WHERE id = \? LIMIT \?`).
+		WithArgs(int64(5), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "deleted_at"}).AddRow(5, "fresh", nil))
+	assert.NoError(t, repo.Reload(context.Background(), post))
+	assert.Equal(t, "fresh", post.Title)
+
+	mock.ExpectQuery(`SELECT id, title, deleted_at FROM posts WHERE id = \? LIMIT \?`).
+		WithArgs(int64(5), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "deleted_at"}))
+	assert.ErrorIs(t, repo.Reload(context.Background(), post), sql.ErrNoRows)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,82 @@
+package query
+
+import "fmt"
+
+// AdvisoryLockBuilder builds the statement that takes or releases an
+// advisory lock on a string key.
+//
+// Example:
+//
+//	sql, args, err := query.AdvisoryLock("nightly-report").Try().SQL()
+//	 MySQL:    "SELECT GET_LOCK(?, 0)"
+//	 Postgres: "SELECT pg_try_advisory_lock(hashtext($1))"
+//	 args: ["nightly-report"]
+type AdvisoryLockBuilder struct {
+	key    string
+	try    bool
+	xact   bool
+	unlock bool
+}
+
+// AdvisoryLock starts a statement that acquires the advisory lock on key,
+// waiting until it is available.
+func AdvisoryLock(key string) *AdvisoryLockBuilder {
+	return &AdvisoryLockBuilder{key: key}
+}
+
+// AdvisoryUnlock starts a statement that releases the session advisory lock on key.
+func AdvisoryUnlock(key string) *AdvisoryLockBuilder {
+	return &AdvisoryLockBuilder{key: key, unlock: true}
+}
+
+// Try returns at once instead of waiting; the statement yields whether the
+// lock was acquired.
+func (b *AdvisoryLockBuilder) Try() *AdvisoryLockBuilder {
+	b.try = true
+	return b
+}
+
+// Xact scopes the lock to the current transaction, releasing it on commit
+// or rollback (Postgres).
+func (b *AdvisoryLockBuilder) Xact() *AdvisoryLockBuilder {
+	b.xact = true
+	return b
+}
+
+// Build assembles the statement and returns it with args, or an empty string
+// and nil args when it is invalid; use SQL to get the reason.
+func (b *AdvisoryLockBuilder) Build() (string, []any) {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return "", nil
+	}
+	return sql, args
+}
+
+// SQL assembles the statement for the active dialect. It returns an error
+// when a transaction-scoped lock is not supported or is released explicitly.
+func (b *AdvisoryLockBuilder) SQL() (string, []any, error) {
+	bd := newBinder()
+	d := bd.dialect
+	ad, ok := d.(AdvisoryLockDialect)
+
+	var expr string
+	switch {
+	case !ok:
+		return "", nil, unsupported(d, "advisory lock")
+	case b.unlock && (b.try || b.xact):
+		return "", nil, fmt.Errorf("%w: advisory unlock with Try or Xact", ErrConflictingClause)
+	case b.unlock:
+		expr = ad.AdvisoryUnlock()
+	case b.xact && !Supports(d, FeatureAdvisoryXactLock):
+		return "", nil, unsupported(d, "transaction-scoped advisory lock")
+	default:
+		expr = ad.AdvisoryLock(b.try, b.xact)
+	}
+
+	call, err := bd.bind(Condition{Expr: expr, Args: []any{b.key}})
+	if err != nil {
+		return "", nil, err
+	}
+	return "SELECT " + call, bd.args, nil
+}
@@ -0,0 +1,60 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisoryLock_MySQL(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	tests := []struct {
+		name string
+		b    *query.AdvisoryLockBuilder
+		want string
+	}{
+		{"try", query.AdvisoryLock("job").Try(), "SELECT GET_LOCK(?, 0)"},
+		{"wait", query.AdvisoryLock("job"), "SELECT GET_LOCK(?, -1)"},
+		{"unlock", query.AdvisoryUnlock("job"), "SELECT RELEASE_LOCK(?)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.b.SQL()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, sql)
+			assert.Equal(t, []any{"job"}, args)
+		})
+	}
+
+	_, _, err := query.AdvisoryLock("job").Xact().SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+}
+
+func TestAdvisoryLock_Postgres(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	tests := []struct {
+		name string
+		b    *query.AdvisoryLockBuilder
+		want string
+	}{
+		{"try", query.AdvisoryLock("job").Try(), "SELECT pg_try_advisory_lock(hashtext($1))"},
+		{"wait", query.AdvisoryLock("job"), "SELECT pg_advisory_lock(hashtext($1))"},
+		{"try xact", query.AdvisoryLock("job").Try().Xact(), "SELECT pg_try_advisory_xact_lock(hashtext($1))"},
+		{"xact", query.AdvisoryLock("job").Xact(), "SELECT pg_advisory_xact_lock(hashtext($1))"},
+		{"unlock", query.AdvisoryUnlock("job"), "SELECT pg_advisory_unlock(hashtext($1))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.b.Build()
+			assert.Equal(t, tt.want, sql)
+			assert.Equal(t, []any{"job"}, args)
+		})
+	}
+
+	_, _, err := query.AdvisoryUnlock("job").Xact().SQL()
+	assert.ErrorIs(t, err, query.ErrConflictingClause)
+}
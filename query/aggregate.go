@@ -0,0 +1,40 @@
+package query
+
+import "fmt"
+
+// aggregate renders "FN(expr)". A string argument is treated as a column
+// name (or "*"), any other value is rendered through operand.
+func aggregate(fn string, expr any) Condition {
+	if col, ok := expr.(string); ok {
+		return Condition{Expr: fmt.Sprintf("%s(%s)", fn, col)}
+	}
+	e, args := operand(expr)
+	return Condition{Expr: fmt.Sprintf("%s(%s)", fn, e), Args: args}
+}
+
+// Count creates "COUNT(expr)".
+// Example: query.Count("*")
+func Count(expr any) Condition {
+	return aggregate("COUNT", expr)
+}
+
+// Sum creates "SUM(expr)".
+// Example: query.Sum(query.Case().When(query.C("paid").Eq(true), query.C("amount")).Else(0))
+func Sum(expr any) Condition {
+	return aggregate("SUM", expr)
+}
+
+// Avg creates "AVG(expr)".
+func Avg(expr any) Condition {
+	return aggregate("AVG", expr)
+}
+
+// Min creates "MIN(expr)".
+func Min(expr any) Condition {
+	return aggregate("MIN", expr)
+}
+
+// Max creates "MAX(expr)".
+func Max(expr any) Condition {
+	return aggregate("MAX", expr)
+}
@@ -1,21 +1,26 @@
 package query
 
 import (
+	"fmt"
 	"strings"
 )
 
 // Builder builds SQL SELECT queries in a fluent DSL style.
-// It supports SELECT, WHERE, ORDER BY, LIMIT, OFFSET.
+// It supports SELECT, WHERE, ORDER BY, LIMIT, OFFSET and row locking.
+//
+// Building never modifies the Builder, so Build and SQL can be called
+// repeatedly and from several goroutines. Use Clone to derive variants of a
+// shared base query before adding clauses to it.
 type Builder struct {
-	table            string
-	columns          []string
-	where            []Condition
-	args             []any
-	order            string
-	limit            int
-	offset           int
-	joins            []joinClause
-	placeholderIndex int // tracks placeholders for dialects
+	table   string
+	columns []Condition
+	where   []Condition
+	order   []Condition
+	limit   int
+	offset  int
+	joins   []joinClause
+	windows []namedWindow
+	lock    lockClause
 }
 
 // From creates a new Builder for a given table.
@@ -23,9 +28,39 @@ func From(table string) *Builder {
 	return &Builder{table: table, limit: -1, offset: -1}
 }
 
+// Clone returns a deep copy of the builder. Clauses added to the copy do not
+// affect the original, which makes it safe to keep a scoped base query and
+// derive variants from it, including across goroutines.
+//
+// Example:
+//
+//	base := query.From("orders").Where(query.C("tenant_id").Eq(tenantID))
+//	open := base.Clone().Where(query.C("status").Eq("open"))
+//	paid := base.Clone().Where(query.C("status").Eq("paid"))
+func (b *Builder) Clone() *Builder {
+	c := *b
+	c.columns = cloneSlice(b.columns)
+	c.where = cloneSlice(b.where)
+	c.order = cloneSlice(b.order)
+	c.joins = cloneSlice(b.joins)
+	c.windows = cloneSlice(b.windows)
+	c.lock.of = cloneSlice(b.lock.of)
+	return &c
+}
+
 // Select specifies the columns to select.
 func (b *Builder) Select(cols ...string) *Builder {
-	b.columns = cols
+	b.columns = make([]Condition, len(cols))
+	for i, col := range cols {
+		b.columns[i] = Condition{Expr: col}
+	}
+	return b
+}
+
+// SelectExpr appends expressions with bound arguments to the select list.
+// Example: .SelectExpr(query.Sum(query.Case().When(cond, query.C("amount")).Else(0)).As("paid"))
+func (b *Builder) SelectExpr(exprs ...Condition) *Builder {
+	b.columns = append(b.columns, exprs...)
 	return b
 }
 
@@ -35,9 +70,20 @@ func (b *Builder) Where(conds ...Condition) *Builder {
 	return b
 }
 
-// OrderBy sets the ORDER BY clause.
+// OrderBy sets the ORDER BY clause. An empty order clears it.
 func (b *Builder) OrderBy(order string) *Builder {
-	b.order = order
+	if order == "" {
+		b.order = nil
+		return b
+	}
+	b.order = []Condition{{Expr: order}}
+	return b
+}
+
+// OrderByExpr appends expressions with bound arguments to the ORDER BY clause.
+// Example: .OrderByExpr(query.Case().When(query.C("vip").Eq(true), 0).Else(1).End())
+func (b *Builder) OrderByExpr(exprs ...Condition) *Builder {
+	b.order = append(b.order, exprs...)
 	return b
 }
 
@@ -54,13 +100,42 @@ func (b *Builder) Offset(offset int) *Builder {
 }
 
 // Build assembles the SQL string and returns args.
+// It returns an empty string and nil args when the query is invalid;
+// use SQL to get the reason.
 func (b *Builder) Build() (string, []any) {
-	b.args = nil
-	b.placeholderIndex = 0
+	sql, args, err := b.SQL()
+	if err != nil {
+		return "", nil
+	}
+	return sql, args
+}
+
+// SQL assembles the SQL string and returns it with args, or an error when the
+// table is missing, a join kind is unknown or a condition's placeholders do
+// not match its arguments.
+func (b *Builder) SQL() (string, []any, error) {
+	bd := newBinder()
+	sql, err := b.render(bd)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql, bd.args, nil
+}
+
+// render writes the SELECT statement using bd, so it can be embedded in
+// another statement (INSERT ... SELECT, MERGE ... USING) with placeholders
+// numbered across both.
+func (b *Builder) render(bd *binder) (string, error) {
+	if b.table == "" {
+		return "", ErrEmptyTable
+	}
 
 	cols := "*"
 	if len(b.columns) > 0 {
-		cols = strings.Join(b.columns, ", ")
+		var err error
+		if cols, err = bd.list(b.columns, ", "); err != nil {
+			return "", err
+		}
 	}
 
 	sql := strings.Builder{}
@@ -70,51 +145,65 @@ func (b *Builder) Build() (string, []any) {
 	sql.WriteString(b.table)
 
 	for _, j := range b.joins {
+		join, err := j.render()
+		if err != nil {
+			return "", err
+		}
 		sql.WriteString(" ")
-		sql.WriteString(j.kind)
-		sql.WriteString(" ")
-		sql.WriteString(j.table)
-		sql.WriteString(" ON ")
-		sql.WriteString(j.on)
+		sql.WriteString(join)
 	}
 
 	// WHERE
 	if len(b.where) > 0 {
+		where, err := bd.list(b.where, " AND ")
+		if err != nil {
+			return "", err
+		}
 		sql.WriteString(" WHERE ")
-		parts := make([]string, len(b.where))
-		for i, cond := range b.where {
-			expr := cond.Expr
-			for j := 0; j < len(cond.Args); j++ {
-				b.placeholderIndex++
-				expr = strings.Replace(expr, "?", GetDialect().Placeholder(b.placeholderIndex), 1)
-			}
-			parts[i] = expr
-			b.args = append(b.args, cond.Args...)
+		sql.WriteString(where)
+	}
+
+	// WINDOW
+	if len(b.windows) > 0 {
+		sql.WriteString(" WINDOW ")
+		parts := make([]string, len(b.windows))
+		for i, w := range b.windows {
+			parts[i] = fmt.Sprintf("%s AS (%s)", w.name, w.spec)
 		}
-		sql.WriteString(strings.Join(parts, " AND "))
+		sql.WriteString(strings.Join(parts, ", "))
 	}
 
 	// ORDER BY
-	if b.order != "" {
+	if len(b.order) > 0 {
+		order, err := bd.list(b.order, ", ")
+		if err != nil {
+			return "", err
+		}
 		sql.WriteString(" ORDER BY ")
-		sql.WriteString(b.order)
+		sql.WriteString(order)
 	}
 
 	// LIMIT
 	if b.limit >= 0 {
-		b.placeholderIndex++
 		sql.WriteString(" LIMIT ")
-		sql.WriteString(GetDialect().Placeholder(b.placeholderIndex))
-		b.args = append(b.args, b.limit)
+		sql.WriteString(bd.arg(b.limit))
 	}
 
 	// OFFSET
 	if b.offset >= 0 {
-		b.placeholderIndex++
 		sql.WriteString(" OFFSET ")
-		sql.WriteString(GetDialect().Placeholder(b.placeholderIndex))
-		b.args = append(b.args, b.offset)
+		sql.WriteString(bd.arg(b.offset))
+	}
+
+	// FOR UPDATE / FOR SHARE
+	lock, err := b.lock.render(bd.dialect)
+	if err != nil {
+		return "", err
+	}
+	if lock != "" {
+		sql.WriteString(" ")
+		sql.WriteString(lock)
 	}
 
-	return sql.String(), b.args
+	return sql.String(), nil
 }
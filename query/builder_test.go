@@ -82,3 +82,37 @@ func TestBuilder_AllDialects(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilder_Validation(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	_, _, err := query.From("").Select("id").SQL()
+	assert.ErrorIs(t, err, query.ErrEmptyTable)
+
+	_, _, err = query.Delete("").SQL()
+	assert.ErrorIs(t, err, query.ErrEmptyTable)
+
+	_, _, err = query.From("users").JoinKind("sideways", "orders", "users.id = orders.user_id").SQL()
+	assert.ErrorIs(t, err, query.ErrUnknownJoin)
+
+	sql, _, err := query.From("users").
+		JoinKind("left", "orders", "users.id = orders.user_id").
+		JoinKind("cross", "regions", "").
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users LEFT JOIN orders ON users.id = orders.user_id CROSS JOIN regions", sql)
+
+	// Empty IN renders a constant predicate instead of "IN ()"
+	sql, args, err := query.From("users").Select("id").Where(query.C("id").In()).SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE 1 = 0", sql)
+	assert.Empty(t, args)
+
+	sql, _, err = query.From("users").Select("id").Where(query.C("id").NotIn()).SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE 1 = 1", sql)
+
+	sql, _, err = query.From("users").OrderBy("name").OrderBy("").SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users", sql)
+}
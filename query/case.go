@@ -0,0 +1,78 @@
+package query
+
+import "strings"
+
+// CaseBuilder builds a searched CASE expression.
+//
+// Example:
+//
+//	query.Sum(query.Case().
+//		When(query.C("status").Eq("paid"), query.C("amount")).
+//		Else(0))
+//
+//	 MySQL:    "SUM(CASE WHEN status = ? THEN amount ELSE ? END)"
+//	 Postgres: "SUM(CASE WHEN status = $1 THEN amount ELSE $2 END)"
+//	 args: ["paid", 0]
+type CaseBuilder struct {
+	whens   []caseWhen
+	elseVal any
+	hasElse bool
+}
+
+// caseWhen stores a single WHEN ... THEN ... branch.
+type caseWhen struct {
+	cond  Condition
+	value any
+}
+
+// Case starts a new CASE expression.
+func Case() *CaseBuilder {
+	return &CaseBuilder{}
+}
+
+// When adds a "WHEN cond THEN value" branch.
+// The value may be a Column, Condition, RawSQL or a plain value to bind.
+func (c *CaseBuilder) When(cond Condition, value any) *CaseBuilder {
+	c.whens = append(c.whens, caseWhen{cond: cond, value: value})
+	return c
+}
+
+// Else sets the "ELSE value" branch.
+func (c *CaseBuilder) Else(value any) *CaseBuilder {
+	c.elseVal = value
+	c.hasElse = true
+	return c
+}
+
+// End renders the CASE expression as a Condition so it can be used in
+// Select, Where and OrderBy. Arguments are collected in the order they
+// appear in the expression.
+func (c *CaseBuilder) End() Condition {
+	var sb strings.Builder
+	var args []any
+
+	sb.WriteString("CASE")
+	for _, w := range c.whens {
+		expr, vargs := operand(w.value)
+		sb.WriteString(" WHEN ")
+		sb.WriteString(w.cond.Expr)
+		sb.WriteString(" THEN ")
+		sb.WriteString(expr)
+		args = append(args, w.cond.Args...)
+		args = append(args, vargs...)
+	}
+	if c.hasElse {
+		expr, vargs := operand(c.elseVal)
+		sb.WriteString(" ELSE ")
+		sb.WriteString(expr)
+		args = append(args, vargs...)
+	}
+	sb.WriteString(" END")
+
+	return Condition{Expr: sb.String(), Args: args}
+}
+
+// As renders the CASE expression with an alias: "CASE ... END AS alias".
+func (c *CaseBuilder) As(alias string) Condition {
+	return c.End().As(alias)
+}
@@ -0,0 +1,68 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseExpression(t *testing.T) {
+	cond := query.Case().
+		When(query.C("status").Eq("paid"), query.C("amount")).
+		When(query.C("status").Eq("refunded"), query.Raw("-amount")).
+		Else(0).
+		End()
+
+	assert.Equal(t, "CASE WHEN status = ? THEN amount WHEN status = ? THEN -amount ELSE ? END", cond.Expr)
+	assert.Equal(t, []any{"paid", "refunded", 0}, cond.Args)
+
+	// Without ELSE, bound THEN values
+	cond = query.Case().When(query.C("age").Lt(18), "minor").End()
+	assert.Equal(t, "CASE WHEN age < ? THEN ? END", cond.Expr)
+	assert.Equal(t, []any{18, "minor"}, cond.Args)
+}
+
+func TestAggregates(t *testing.T) {
+	assert.Equal(t, "COUNT(*)", query.Count("*").Expr)
+	assert.Equal(t, "AVG(price) AS avg_price", query.Avg("price").As("avg_price").Expr)
+	assert.Equal(t, "MIN(price)", query.Min("price").Expr)
+	assert.Equal(t, "MAX(price)", query.Max("price").Expr)
+
+	cond := query.Sum(query.Case().When(query.C("status").Eq("paid"), query.C("amount")).Else(0)).As("paid")
+	assert.Equal(t, "SUM(CASE WHEN status = ? THEN amount ELSE ? END) AS paid", cond.Expr)
+	assert.Equal(t, []any{"paid", 0}, cond.Args)
+}
+
+func TestBuilder_CaseInSelectWhereOrderBy(t *testing.T) {
+	paid := query.Sum(query.Case().
+		When(query.C("status").Eq("paid"), query.C("amount")).
+		Else(0)).As("paid_total")
+
+	build := func() (string, []any) {
+		return query.From("orders").
+			Select("customer_id").
+			SelectExpr(paid).
+			Where(query.C("tenant_id").Eq(7)).
+			Where(query.Case().When(query.C("vip").Eq(true), query.Raw("TRUE")).Else(query.Raw("FALSE")).End()).
+			OrderByExpr(query.Case().When(query.C("vip").Eq(true), 0).Else(1).End().Desc()).
+			Limit(5).
+			Build()
+	}
+
+	query.SetDialect(query.MySQLDialect{})
+	sql, args := build()
+	assert.Equal(t, "SELECT customer_id, SUM(CASE WHEN status = ? THEN amount ELSE ? END) AS paid_total FROM orders "+
+		"WHERE tenant_id = ? AND CASE WHEN vip = ? THEN TRUE ELSE FALSE END "+
+		"ORDER BY CASE WHEN vip = ? THEN ? ELSE ? END DESC LIMIT ?", sql)
+	assert.Equal(t, []any{"paid", 0, 7, true, true, 0, 1, 5}, args)
+
+	query.SetDialect(query.PostgresDialect{})
+	sql, args = build()
+	assert.Equal(t, "SELECT customer_id, SUM(CASE WHEN status = $1 THEN amount ELSE $2 END) AS paid_total FROM orders "+
+		"WHERE tenant_id = $3 AND CASE WHEN vip = $4 THEN TRUE ELSE FALSE END "+
+		"ORDER BY CASE WHEN vip = $5 THEN $6 ELSE $7 END DESC LIMIT $8", sql)
+	assert.Equal(t, []any{"paid", 0, 7, true, true, 0, 1, 5}, args)
+
+	query.SetDialect(query.MySQLDialect{})
+}
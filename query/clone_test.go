@@ -0,0 +1,86 @@
+package query_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_BuildIsRepeatable(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	b := query.From("orders").Where(query.C("tenant_id").Eq(1)).Limit(10)
+	sql1, args1 := b.Build()
+	sql2, args2 := b.Build()
+	assert.Equal(t, "SELECT * FROM orders WHERE tenant_id = $1 LIMIT $2", sql1)
+	assert.Equal(t, sql1, sql2)
+	assert.Equal(t, args1, args2)
+
+	d := query.Delete("orders").Where(query.C("id").Eq(9))
+	sql1, args1 = d.Build()
+	sql2, args2 = d.Build()
+	assert.Equal(t, "DELETE FROM orders WHERE id = $1", sql2)
+	assert.Equal(t, sql1, sql2)
+	assert.Equal(t, []any{9}, args2)
+	assert.Equal(t, args1, args2)
+}
+
+func TestBuilder_Clone(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	base := query.From("orders").Select("id").Where(query.C("tenant_id").Eq(1))
+	open := base.Clone().Where(query.C("status").Eq("open")).OrderBy("id")
+	paid := base.Clone().Where(query.C("status").Eq("paid")).Limit(5)
+
+	sql, args := base.Build()
+	assert.Equal(t, "SELECT id FROM orders WHERE tenant_id = ?", sql)
+	assert.Equal(t, []any{1}, args)
+
+	sql, args = open.Build()
+	assert.Equal(t, "SELECT id FROM orders WHERE tenant_id = ? AND status = ? ORDER BY id", sql)
+	assert.Equal(t, []any{1, "open"}, args)
+
+	sql, args = paid.Build()
+	assert.Equal(t, "SELECT id FROM orders WHERE tenant_id = ? AND status = ? LIMIT ?", sql)
+	assert.Equal(t, []any{1, "paid", 5}, args)
+
+	del := query.Delete("orders").Where(query.C("tenant_id").Eq(1))
+	sql, _ = del.Clone().Where(query.C("id").Eq(2)).Build()
+	assert.Equal(t, "DELETE FROM orders WHERE tenant_id = ? AND id = ?", sql)
+	sql, _ = del.Build()
+	assert.Equal(t, "DELETE FROM orders WHERE tenant_id = ?", sql)
+
+	ins := query.InsertInto("users").Columns("name").Values("Alice")
+	sql, args = ins.Clone().Values("Bob").ToSQL()
+	assert.Equal(t, "INSERT INTO users (name) VALUES (?), (?)", sql)
+	assert.Equal(t, []any{"Alice", "Bob"}, args)
+	sql, args = ins.ToSQL()
+	assert.Equal(t, "INSERT INTO users (name) VALUES (?)", sql)
+	assert.Equal(t, []any{"Alice"}, args)
+}
+
+func TestBuilder_ConcurrentBuild(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	base := query.From("orders").Where(query.C("tenant_id").Eq(1))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sql, args := base.Clone().Where(query.C("id").Eq(i)).Build()
+			assert.Equal(t, "SELECT * FROM orders WHERE tenant_id = ? AND id = ?", sql)
+			assert.Equal(t, []any{1, i}, args, fmt.Sprint(i))
+
+			sql, args = base.Build()
+			assert.Equal(t, "SELECT * FROM orders WHERE tenant_id = ?", sql)
+			assert.Equal(t, []any{1}, args)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -6,10 +6,13 @@ import (
 
 // DeleteBuilder builds SQL DELETE queries with dialect support.
 type DeleteBuilder struct {
-	table            string
-	where            []Condition
-	args             []any
-	placeholderIndex int
+	table     string
+	where     []Condition
+	using     []string
+	joins     []joinClause
+	returning []string
+	order     string
+	limit     int
 }
 
 // Delete creates a new DeleteBuilder for the given table.
@@ -17,7 +20,7 @@ type DeleteBuilder struct {
 // Example:
 //
 //	q := query.Delete("users").
-//		Where("id = ?", 42)
+//		Where(query.C("id").Eq(42))
 //
 //	sql, args := q.Build()
 //	 MySQL:    "DELETE FROM users WHERE id = ?"
@@ -26,9 +29,21 @@ type DeleteBuilder struct {
 func Delete(table string) *DeleteBuilder {
 	return &DeleteBuilder{
 		table: table,
+		limit: -1,
 	}
 }
 
+// Clone returns a deep copy of the builder so variants can be derived
+// without affecting the original.
+func (b *DeleteBuilder) Clone() *DeleteBuilder {
+	c := *b
+	c.where = cloneSlice(b.where)
+	c.using = cloneSlice(b.using)
+	c.joins = cloneSlice(b.joins)
+	c.returning = cloneSlice(b.returning)
+	return &c
+}
+
 // Where adds a WHERE clause to the DELETE query.
 // Multiple calls are joined with AND.
 func (b *DeleteBuilder) Where(conds ...Condition) *DeleteBuilder {
@@ -36,27 +51,135 @@ func (b *DeleteBuilder) Where(conds ...Condition) *DeleteBuilder {
 	return b
 }
 
+// Using adds tables to the USING clause (Postgres), to be joined to the
+// target table in WHERE.
+// Example: .Using("customers").Where(query.Expr("orders.customer_id = customers.id"))
+func (b *DeleteBuilder) Using(tables ...string) *DeleteBuilder {
+	b.using = append(b.using, tables...)
+	return b
+}
+
+// Join adds an INNER JOIN for a multi-table delete (MySQL, MariaDB):
+// "DELETE t FROM t INNER JOIN x ON ...". Only rows of the target table are removed.
+func (b *DeleteBuilder) Join(table, on string) *DeleteBuilder {
+	b.joins = append(b.joins, joinClause{"INNER JOIN", table, on})
+	return b
+}
+
+// LeftJoin adds a LEFT JOIN for a multi-table delete (MySQL, MariaDB).
+func (b *DeleteBuilder) LeftJoin(table, on string) *DeleteBuilder {
+	b.joins = append(b.joins, joinClause{"LEFT JOIN", table, on})
+	return b
+}
+
+// Returning specifies columns of the removed rows to return
+// (Postgres, MariaDB).
+func (b *DeleteBuilder) Returning(cols ...string) *DeleteBuilder {
+	b.returning = append(b.returning, cols...)
+	return b
+}
+
+// OrderBy sets the ORDER BY clause so bounded batches remove rows in a
+// predictable order (MySQL, MariaDB).
+func (b *DeleteBuilder) OrderBy(order string) *DeleteBuilder {
+	b.order = order
+	return b
+}
+
+// Limit caps the number of rows removed (MySQL, MariaDB).
+func (b *DeleteBuilder) Limit(limit int) *DeleteBuilder {
+	b.limit = limit
+	return b
+}
+
 // Build assembles the SQL DELETE query string and returns it with args.
-// It rewrites placeholders depending on the active dialect.
+// It rewrites placeholders depending on the active dialect and returns an
+// empty string and nil args when the query is invalid; use SQL to get the reason.
 func (b *DeleteBuilder) Build() (string, []any) {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return "", nil
+	}
+	return sql, args
+}
+
+// SQL assembles the SQL DELETE query string and returns it with args, or an
+// error when the table is missing, a clause is not supported by the dialect
+// or a condition's placeholders do not match its arguments.
+func (b *DeleteBuilder) SQL() (string, []any, error) {
+	if b.table == "" {
+		return "", nil, ErrEmptyTable
+	}
+
+	bd := newBinder()
+	if err := b.checkDialect(bd.dialect); err != nil {
+		return "", nil, err
+	}
+
 	var sql strings.Builder
-	sql.WriteString("DELETE FROM ")
+	sql.WriteString("DELETE ")
+	if len(b.joins) > 0 {
+		sql.WriteString(b.table)
+		sql.WriteString(" ")
+	}
+	sql.WriteString("FROM ")
 	sql.WriteString(b.table)
 
+	for _, j := range b.joins {
+		join, err := j.render()
+		if err != nil {
+			return "", nil, err
+		}
+		sql.WriteString(" ")
+		sql.WriteString(join)
+	}
+
+	if len(b.using) > 0 {
+		sql.WriteString(" USING ")
+		sql.WriteString(strings.Join(b.using, ", "))
+	}
+
 	if len(b.where) > 0 {
-		sql.WriteString(" WHERE ")
-		parts := make([]string, len(b.where))
-		for i, cond := range b.where {
-			expr := cond.Expr
-			for j := 0; j < len(cond.Args); j++ {
-				b.placeholderIndex++
-				expr = strings.Replace(expr, "?", GetDialect().Placeholder(b.placeholderIndex), 1)
-			}
-			parts[i] = expr
-			b.args = append(b.args, cond.Args...)
+		where, err := bd.list(b.where, " AND ")
+		if err != nil {
+			return "", nil, err
 		}
-		sql.WriteString(strings.Join(parts, " AND "))
+		sql.WriteString(" WHERE ")
+		sql.WriteString(where)
+	}
+
+	if b.order != "" {
+		sql.WriteString(" ORDER BY ")
+		sql.WriteString(b.order)
+	}
+
+	if b.limit >= 0 {
+		sql.WriteString(" LIMIT ")
+		sql.WriteString(bd.arg(b.limit))
 	}
 
-	return sql.String(), b.args
+	if len(b.returning) > 0 {
+		sql.WriteString(" RETURNING ")
+		sql.WriteString(strings.Join(b.returning, ", "))
+	}
+
+	return sql.String(), bd.args, nil
+}
+
+// checkDialect reports clauses that the dialect cannot express.
+func (b *DeleteBuilder) checkDialect(d Dialect) error {
+	orderLimit := b.order != "" || b.limit >= 0
+	switch {
+	case len(b.returning) > 0 && !Supports(d, FeatureDeleteReturning):
+		return unsupported(d, "DELETE ... RETURNING")
+	case len(b.using) > 0 && !Supports(d, FeatureDeleteUsing):
+		return unsupported(d, "DELETE ... USING")
+	case len(b.joins) > 0 && !Supports(d, FeatureDeleteJoin):
+		return unsupported(d, "multi-table DELETE ... JOIN")
+	case orderLimit && !Supports(d, FeatureDeleteOrderLimit):
+		return unsupported(d, "DELETE ... ORDER BY/LIMIT")
+	case orderLimit && len(b.joins) > 0:
+		return unsupported(d, "multi-table DELETE ... ORDER BY/LIMIT")
+	}
+	return nil
 }
@@ -55,3 +55,64 @@ func TestDeleteBuilder_NoWhere(t *testing.T) {
 	assert.Equal(t, expectedSQL, sql, "got %q, want %q", sql, expectedSQL)
 	assert.Empty(t, args, "expected no args, got %v", args)
 }
+
+func TestDeleteBuilder_ReturningAndUsing_Postgres(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.Delete("orders").
+		Using("customers").
+		Where(query.Expr("orders.customer_id = customers.id"), query.C("customers.status").Eq("closed")).
+		Returning("orders.id", "orders.amount").
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM orders USING customers WHERE orders.customer_id = customers.id "+
+		"AND customers.status = $1 RETURNING orders.id, orders.amount", sql)
+	assert.Equal(t, []any{"closed"}, args)
+
+	_, _, err = query.Delete("orders").Limit(10).SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+
+	_, _, err = query.Delete("orders").Join("customers", "orders.customer_id = customers.id").SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+}
+
+func TestDeleteBuilder_JoinAndLimit_MySQL(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.Delete("orders").
+		Join("customers", "orders.customer_id = customers.id").
+		Where(query.C("customers.status").Eq("closed")).
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE orders FROM orders INNER JOIN customers ON orders.customer_id = customers.id "+
+		"WHERE customers.status = ?", sql)
+	assert.Equal(t, []any{"closed"}, args)
+
+	sql, args, err = query.Delete("events").
+		Where(query.C("created_at").Lt("2024-01-01")).
+		OrderBy("created_at").
+		Limit(500).
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM events WHERE created_at < ? ORDER BY created_at LIMIT ?", sql)
+	assert.Equal(t, []any{"2024-01-01", 500}, args)
+
+	_, _, err = query.Delete("events").Returning("id").SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+
+	_, _, err = query.Delete("events").Using("x").SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+
+	_, _, err = query.Delete("orders").LeftJoin("customers", "orders.customer_id = customers.id").Limit(1).SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+}
+
+func TestDeleteBuilder_Returning_MariaDB(t *testing.T) {
+	query.SetDialect(query.MariaDBDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, _, err := query.Delete("events").Where(query.C("id").Eq(1)).Returning("id").SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM events WHERE id = ? RETURNING id", sql)
+}
@@ -2,11 +2,47 @@ package query
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 )
 
+// Feature names an optional SQL capability that not every dialect supports.
+type Feature int
+
+const (
+	// FeatureUpdateReturning is UPDATE ... RETURNING.
+	FeatureUpdateReturning Feature = iota + 1
+	// FeatureUpdateOrderLimit is UPDATE ... ORDER BY ... LIMIT.
+	FeatureUpdateOrderLimit
+	// FeatureDeleteReturning is DELETE ... RETURNING.
+	FeatureDeleteReturning
+	// FeatureDeleteUsing is DELETE FROM t USING other ....
+	FeatureDeleteUsing
+	// FeatureDeleteJoin is the multi-table DELETE t FROM t JOIN other ....
+	FeatureDeleteJoin
+	// FeatureDeleteOrderLimit is DELETE ... ORDER BY ... LIMIT.
+	FeatureDeleteOrderLimit
+	// FeatureMerge is the MERGE statement (Postgres 15+).
+	FeatureMerge
+	// FeatureForShare is the SELECT ... FOR SHARE row lock.
+	FeatureForShare
+	// FeatureLockOf is FOR UPDATE OF tables.
+	FeatureLockOf
+	// FeatureAdvisoryXactLock is an advisory lock released when the
+	// transaction ends.
+	FeatureAdvisoryXactLock
+	// FeatureInsertReturning is INSERT ... RETURNING.
+	FeatureInsertReturning
+)
+
 // Dialect defines the behavior that differs across SQL databases.
 // For example, how parameter placeholders are represented.
+//
+// Optional capabilities are described by separate interfaces (FeatureDialect,
+// PatternDialect, JSONDialect, AdvisoryLockDialect, UpsertDialect and
+// DefaultFuncDialect). A dialect that does not implement one of them makes
+// the statements needing it fail with ErrUnsupported, except ILike, which
+// falls back to "LOWER(col) LIKE LOWER(?)".
 type Dialect interface {
 	// Placeholder returns the placeholder string for the given index.
 	// Example: $1 for PostgreSQL, ? for MySQL/MariaDB.
@@ -15,6 +51,71 @@ type Dialect interface {
 	Name() string
 }
 
+// FeatureDialect is implemented by dialects that support optional clauses.
+type FeatureDialect interface {
+	// Supports reports whether the dialect supports an optional feature.
+	Supports(feature Feature) bool
+}
+
+// PatternDialect is implemented by dialects that render pattern and
+// full-text matching.
+type PatternDialect interface {
+	// ILike renders a case-insensitive LIKE on column with a single "?" marker.
+	// Dialects without native support fall back to "LOWER(col) LIKE LOWER(?)".
+	ILike(column string) string
+	// Regexp renders a regular expression match on column with a single "?" marker.
+	Regexp(column string) string
+	// FullText renders a full-text search over at least one column with a
+	// single "?" marker.
+	FullText(columns ...string) string
+}
+
+// JSONDialect is implemented by dialects that render JSON operators.
+type JSONDialect interface {
+	// JSONExtract renders the text value found at path inside a JSON column.
+	JSONExtract(column string, path ...string) string
+	// JSONContains renders a containment test of a JSON document bound to a single "?" marker.
+	JSONContains(column string) string
+	// JSONHasKey renders a top-level key existence test with a single "?" marker
+	// and returns the argument to bind for it.
+	JSONHasKey(column, key string) (string, any)
+}
+
+// AdvisoryLockDialect is implemented by dialects with advisory locks.
+type AdvisoryLockDialect interface {
+	// AdvisoryLock renders a call taking an advisory lock on a key bound to a
+	// single "?" marker. With try it returns at once and reports whether the
+	// lock was acquired; otherwise it waits. With xact the lock is released
+	// when the transaction ends (see FeatureAdvisoryXactLock).
+	AdvisoryLock(try, xact bool) string
+	// AdvisoryUnlock renders a call releasing a session advisory lock on a key
+	// bound to a single "?" marker.
+	AdvisoryUnlock() string
+}
+
+// UpsertDialect is implemented by dialects that render INSERT upserts.
+type UpsertDialect interface {
+	// OnConflict renders the upsert clause of an INSERT: on a conflict on the
+	// target columns, overwrite the update columns with the proposed values,
+	// or keep the existing row when update is empty.
+	OnConflict(target, update []string) string
+}
+
+// DefaultFuncDialect is implemented by dialects that render portable
+// column default functions.
+type DefaultFuncDialect interface {
+	// DefaultFunc renders a portable function used as a column default:
+	// "now" for the current timestamp and "uuid" for a random UUID.
+	// It reports false for functions the dialect cannot express.
+	DefaultFunc(name string) (string, bool)
+}
+
+// Supports reports whether d implements FeatureDialect and supports feature.
+func Supports(d Dialect, feature Feature) bool {
+	fd, ok := d.(FeatureDialect)
+	return ok && fd.Supports(feature)
+}
+
 // ------------------
 // Dialect Implementations
 // ------------------
@@ -32,6 +133,82 @@ func (d MySQLDialect) Name() string {
 	return "mysql"
 }
 
+// Supports reports whether MySQL supports feature.
+func (d MySQLDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureUpdateOrderLimit, FeatureDeleteJoin, FeatureDeleteOrderLimit, FeatureForShare, FeatureLockOf:
+		return true
+	default:
+		return false
+	}
+}
+
+// ILike falls back to "LOWER(col) LIKE LOWER(?)".
+func (d MySQLDialect) ILike(column string) string {
+	return fmt.Sprintf("LOWER(%s) LIKE LOWER(?)", column)
+}
+
+// Regexp returns "col REGEXP ?".
+func (d MySQLDialect) Regexp(column string) string {
+	return fmt.Sprintf("%s REGEXP ?", column)
+}
+
+// FullText returns "MATCH(cols) AGAINST(? IN BOOLEAN MODE)".
+func (d MySQLDialect) FullText(columns ...string) string {
+	return fmt.Sprintf("MATCH(%s) AGAINST(? IN BOOLEAN MODE)", strings.Join(columns, ", "))
+}
+
+// JSONExtract returns "JSON_UNQUOTE(JSON_EXTRACT(col, '$.a.b'))".
+func (d MySQLDialect) JSONExtract(column string, path ...string) string {
+	if len(path) == 0 {
+		return column
+	}
+	return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s))", column, quoteLiteral(jsonPath(path)))
+}
+
+// JSONContains returns "JSON_CONTAINS(col, ?)".
+func (d MySQLDialect) JSONContains(column string) string {
+	return fmt.Sprintf("JSON_CONTAINS(%s, ?)", column)
+}
+
+// JSONHasKey returns "JSON_CONTAINS_PATH(col, 'one', ?)" bound to "$.key".
+func (d MySQLDialect) JSONHasKey(column, key string) (string, any) {
+	return fmt.Sprintf("JSON_CONTAINS_PATH(%s, 'one', ?)", column), jsonPath([]string{key})
+}
+
+// AdvisoryLock returns "GET_LOCK(?, 0)", or "GET_LOCK(?, -1)" to wait.
+// MySQL has no transaction-scoped locks, so xact is ignored.
+func (d MySQLDialect) AdvisoryLock(try, _ bool) string {
+	if try {
+		return "GET_LOCK(?, 0)"
+	}
+	return "GET_LOCK(?, -1)"
+}
+
+// AdvisoryUnlock returns "RELEASE_LOCK(?)".
+func (d MySQLDialect) AdvisoryUnlock() string {
+	return "RELEASE_LOCK(?)"
+}
+
+// OnConflict returns "ON DUPLICATE KEY UPDATE col = VALUES(col)".
+// MySQL resolves the conflict on any unique key, so target is only used to
+// render a no-op update when update is empty.
+func (d MySQLDialect) OnConflict(target, update []string) string {
+	return duplicateKeyUpdate(target, update)
+}
+
+// DefaultFunc returns "CURRENT_TIMESTAMP" for now and "UUID()" for uuid.
+func (d MySQLDialect) DefaultFunc(name string) (string, bool) {
+	switch name {
+	case "now":
+		return "CURRENT_TIMESTAMP", true
+	case "uuid":
+		return "UUID()", true
+	default:
+		return "", false
+	}
+}
+
 // MariaDBDialect behaves the same as MySQL for placeholders.
 type MariaDBDialect struct{}
 
@@ -45,6 +222,67 @@ func (d MariaDBDialect) Name() string {
 	return "mariadb"
 }
 
+// Supports reports whether MariaDB supports feature.
+func (d MariaDBDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureUpdateOrderLimit, FeatureDeleteJoin, FeatureDeleteOrderLimit, FeatureDeleteReturning,
+		FeatureInsertReturning:
+		return true
+	default:
+		return false
+	}
+}
+
+// ILike falls back to "LOWER(col) LIKE LOWER(?)".
+func (d MariaDBDialect) ILike(column string) string {
+	return MySQLDialect{}.ILike(column)
+}
+
+// Regexp returns "col REGEXP ?".
+func (d MariaDBDialect) Regexp(column string) string {
+	return MySQLDialect{}.Regexp(column)
+}
+
+// FullText returns "MATCH(cols) AGAINST(? IN BOOLEAN MODE)".
+func (d MariaDBDialect) FullText(columns ...string) string {
+	return MySQLDialect{}.FullText(columns...)
+}
+
+// JSONExtract returns "JSON_UNQUOTE(JSON_EXTRACT(col, '$.a.b'))".
+func (d MariaDBDialect) JSONExtract(column string, path ...string) string {
+	return MySQLDialect{}.JSONExtract(column, path...)
+}
+
+// JSONContains returns "JSON_CONTAINS(col, ?)".
+func (d MariaDBDialect) JSONContains(column string) string {
+	return MySQLDialect{}.JSONContains(column)
+}
+
+// JSONHasKey returns "JSON_CONTAINS_PATH(col, 'one', ?)" bound to "$.key".
+func (d MariaDBDialect) JSONHasKey(column, key string) (string, any) {
+	return MySQLDialect{}.JSONHasKey(column, key)
+}
+
+// AdvisoryLock returns "GET_LOCK(?, 0)", or "GET_LOCK(?, -1)" to wait.
+func (d MariaDBDialect) AdvisoryLock(try, xact bool) string {
+	return MySQLDialect{}.AdvisoryLock(try, xact)
+}
+
+// AdvisoryUnlock returns "RELEASE_LOCK(?)".
+func (d MariaDBDialect) AdvisoryUnlock() string {
+	return MySQLDialect{}.AdvisoryUnlock()
+}
+
+// OnConflict returns "ON DUPLICATE KEY UPDATE col = VALUES(col)".
+func (d MariaDBDialect) OnConflict(target, update []string) string {
+	return MySQLDialect{}.OnConflict(target, update)
+}
+
+// DefaultFunc returns "CURRENT_TIMESTAMP" for now and "UUID()" for uuid.
+func (d MariaDBDialect) DefaultFunc(name string) (string, bool) {
+	return MySQLDialect{}.DefaultFunc(name)
+}
+
 // PostgresDialect uses "$1, $2, ..." placeholders.
 type PostgresDialect struct{}
 
@@ -58,6 +296,112 @@ func (d PostgresDialect) Name() string {
 	return "postgresql"
 }
 
+// Supports reports whether PostgreSQL supports feature.
+func (d PostgresDialect) Supports(feature Feature) bool {
+	switch feature {
+	case FeatureUpdateReturning, FeatureDeleteReturning, FeatureDeleteUsing, FeatureMerge,
+		FeatureForShare, FeatureLockOf, FeatureAdvisoryXactLock, FeatureInsertReturning:
+		return true
+	default:
+		return false
+	}
+}
+
+// ILike returns "col ILIKE ?".
+func (d PostgresDialect) ILike(column string) string {
+	return fmt.Sprintf("%s ILIKE ?", column)
+}
+
+// Regexp returns "col ~ ?".
+func (d PostgresDialect) Regexp(column string) string {
+	return fmt.Sprintf("%s ~ ?", column)
+}
+
+// FullText returns "to_tsvector(col) @@ plainto_tsquery(?)".
+// Multiple columns are concatenated with concat_ws.
+func (d PostgresDialect) FullText(columns ...string) string {
+	doc := columns[0]
+	if len(columns) > 1 {
+		doc = fmt.Sprintf("concat_ws(' ', %s)", strings.Join(columns, ", "))
+	}
+	return fmt.Sprintf("to_tsvector(%s) @@ plainto_tsquery(?)", doc)
+}
+
+// JSONExtract returns "col->'a'->>'b'".
+// Numeric path elements are rendered as array indexes.
+func (d PostgresDialect) JSONExtract(column string, path ...string) string {
+	var sb strings.Builder
+	sb.WriteString(column)
+	for i, p := range path {
+		if i == len(path)-1 {
+			sb.WriteString("->>")
+		} else {
+			sb.WriteString("->")
+		}
+		if isIndex(p) {
+			sb.WriteString(p)
+		} else {
+			sb.WriteString(quoteLiteral(p))
+		}
+	}
+	return sb.String()
+}
+
+// JSONContains returns "col @> ?".
+func (d PostgresDialect) JSONContains(column string) string {
+	return fmt.Sprintf("%s @> ?", column)
+}
+
+// JSONHasKey returns "col ?? ?", where "??" is the escaped JSONB "?" operator.
+func (d PostgresDialect) JSONHasKey(column, key string) (string, any) {
+	return fmt.Sprintf("%s ?? ?", column), key
+}
+
+// AdvisoryLock returns "pg_advisory_lock(hashtext(?))" and its
+// pg_try_advisory_lock / pg_advisory_xact_lock variants.
+func (d PostgresDialect) AdvisoryLock(try, xact bool) string {
+	fn := "pg_"
+	if try {
+		fn += "try_"
+	}
+	fn += "advisory_"
+	if xact {
+		fn += "xact_"
+	}
+	return fn + "lock(hashtext(?))"
+}
+
+// AdvisoryUnlock returns "pg_advisory_unlock(hashtext(?))".
+func (d PostgresDialect) AdvisoryUnlock() string {
+	return "pg_advisory_unlock(hashtext(?))"
+}
+
+// OnConflict returns "ON CONFLICT (target) DO UPDATE SET col = EXCLUDED.col"
+// or "ON CONFLICT (target) DO NOTHING".
+func (d PostgresDialect) OnConflict(target, update []string) string {
+	clause := fmt.Sprintf("ON CONFLICT (%s) ", strings.Join(target, ", "))
+	if len(update) == 0 {
+		return clause + "DO NOTHING"
+	}
+	sets := make([]string, len(update))
+	for i, col := range update {
+		sets[i] = fmt.Sprintf("%s = EXCLUDED.%s", col, col)
+	}
+	return clause + "DO UPDATE SET " + strings.Join(sets, ", ")
+}
+
+// DefaultFunc returns "CURRENT_TIMESTAMP" for now and "gen_random_uuid()" for uuid.
+func (d PostgresDialect) DefaultFunc(name string) (string, bool) {
+	switch name {
+	case "now":
+		return "CURRENT_TIMESTAMP", true
+	case "uuid":
+		return "gen_random_uuid()", true
+	default:
+		return "", false
+	}
+}
+
 // ------------------
 // Global Dialect Management
 // ------------------
@@ -0,0 +1,97 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect-specific expressions (ILike, Regexp, FullText and the JSON
+// operators) are stored in Condition.Expr as markers and rendered by the
+// binder, so they follow the dialect active when the statement is built,
+// like placeholders do, rather than the one active when the condition was
+// created.
+const (
+	markerOpen  = "\x01"
+	markerClose = "\x02"
+	markerSep   = "\x1f"
+)
+
+// dialectExpr returns a marker for the dialect expression kind over fields.
+func dialectExpr(kind string, fields ...string) string {
+	var sb strings.Builder
+	sb.WriteString(markerOpen)
+	sb.WriteString(kind)
+	for _, f := range fields {
+		sb.WriteString(markerSep)
+		sb.WriteString(f)
+	}
+	sb.WriteString(markerClose)
+	return sb.String()
+}
+
+// jsonKeyArg is the argument of a JSONHasKey condition. Its value depends on
+// the dialect and is resolved when it is bound.
+type jsonKeyArg struct {
+	column, key string
+}
+
+// resolveDialectExprs renders the dialect expression markers in expr for d,
+// innermost first so that nested expressions (ILike on a JSON path) work.
+func resolveDialectExprs(d Dialect, expr string) (string, error) {
+	for {
+		i := strings.LastIndex(expr, markerOpen)
+		if i < 0 {
+			return expr, nil
+		}
+		j := strings.Index(expr[i:], markerClose)
+		if j < 0 {
+			return "", fmt.Errorf("query: malformed expression %q", expr)
+		}
+		j += i
+		parts := strings.Split(expr[i+len(markerOpen):j], markerSep)
+		out, err := renderDialectExpr(d, parts[0], parts[1:])
+		if err != nil {
+			return "", err
+		}
+		expr = expr[:i] + out + expr[j+len(markerClose):]
+	}
+}
+
+// renderDialectExpr renders a single dialect expression.
+func renderDialectExpr(d Dialect, kind string, fields []string) (string, error) {
+	switch kind {
+	case "ilike", "regexp", "fulltext":
+		pd, ok := d.(PatternDialect)
+		switch {
+		case !ok && kind == "ilike":
+			return MySQLDialect{}.ILike(fields[0]), nil
+		case !ok:
+			return "", unsupported(d, kind)
+		}
+		switch kind {
+		case "ilike":
+			return pd.ILike(fields[0]), nil
+		case "regexp":
+			return pd.Regexp(fields[0]), nil
+		}
+		if len(fields) == 0 {
+			return "", fmt.Errorf("%w: FullText", ErrNoColumns)
+		}
+		return pd.FullText(fields...), nil
+	case "json", "jsoncontains", "jsonhaskey":
+		jd, ok := d.(JSONDialect)
+		if !ok {
+			return "", unsupported(d, kind)
+		}
+		switch kind {
+		case "json":
+			return jd.JSONExtract(fields[0], fields[1:]...), nil
+		case "jsoncontains":
+			return jd.JSONContains(fields[0]), nil
+		}
+		expr, _ := jd.JSONHasKey(fields[0], fields[1])
+		return expr, nil
+	default:
+		return "", fmt.Errorf("query: unknown expression %q", kind)
+	}
+}
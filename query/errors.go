@@ -0,0 +1,38 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the SQL methods of the builders.
+var (
+	// ErrPlaceholderMismatch is returned when a condition's bind markers
+	// do not match the number of its arguments.
+	ErrPlaceholderMismatch = errors.New("query: placeholder count does not match args")
+	// ErrMissingParam is returned when a named parameter has no value.
+	ErrMissingParam = errors.New("query: missing named parameter")
+	// ErrEmptyTable is returned when a statement has no table.
+	ErrEmptyTable = errors.New("query: table is required")
+	// ErrNoColumns is returned when an INSERT has no columns.
+	ErrNoColumns = errors.New("query: at least one column is required")
+	// ErrNoValues is returned when an INSERT has no VALUES rows.
+	ErrNoValues = errors.New("query: at least one VALUES row is required")
+	// ErrValueCount is returned when a VALUES row does not match the column count.
+	ErrValueCount = errors.New("query: value count does not match column count")
+	// ErrDuplicateColumn is returned when a column is listed more than once.
+	ErrDuplicateColumn = errors.New("query: duplicate column")
+	// ErrUnknownJoin is returned for an unsupported JOIN kind.
+	ErrUnknownJoin = errors.New("query: unknown join kind")
+	// ErrMissingClause is returned when a statement lacks a required clause.
+	ErrMissingClause = errors.New("query: missing required clause")
+	// ErrConflictingClause is returned when a statement combines clauses that exclude each other.
+	ErrConflictingClause = errors.New("query: conflicting clauses")
+	// ErrUnsupported is returned when a clause is not supported by the active dialect.
+	ErrUnsupported = errors.New("query: not supported by dialect")
+)
+
+// unsupported reports that clause is not available in dialect d.
+func unsupported(d Dialect, clause string) error {
+	return fmt.Errorf("%w: %s on %s", ErrUnsupported, clause, d.Name())
+}
@@ -0,0 +1,48 @@
+package query
+
+import "fmt"
+
+// operand renders a value used inside an expression.
+// Columns, conditions, CASE expressions and RawSQL are inlined;
+// anything else is bound as a placeholder argument.
+func operand(v any) (string, []any) {
+	switch v := v.(type) {
+	case Column:
+		return v.name, nil
+	case Condition:
+		return v.Expr, v.Args
+	case *CaseBuilder:
+		cond := v.End()
+		return cond.Expr, cond.Args
+	case RawSQL:
+		return string(v), nil
+	default:
+		return "?", []any{v}
+	}
+}
+
+// As aliases an expression: "expr AS alias".
+// Example: query.Count("*").As("total")
+func (c Condition) As(alias string) Condition {
+	return Condition{Expr: fmt.Sprintf("%s AS %s", c.Expr, alias), Args: c.Args}
+}
+
+// Asc marks an expression as ascending for OrderByExpr: "expr ASC".
+func (c Condition) Asc() Condition {
+	return Condition{Expr: c.Expr + " ASC", Args: c.Args}
+}
+
+// Desc marks an expression as descending for OrderByExpr: "expr DESC".
+func (c Condition) Desc() Condition {
+	return Condition{Expr: c.Expr + " DESC", Args: c.Args}
+}
+
+// cloneSlice returns a copy of s with its own backing array, or nil for an empty slice.
+func cloneSlice[S ~[]E, E any](s S) S {
+	if len(s) == 0 {
+		return nil
+	}
+	out := make(S, len(s))
+	copy(out, s)
+	return out
+}
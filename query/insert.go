@@ -20,6 +20,8 @@ type InsertBuilder struct {
 	table     string
 	columns   []string
 	values    [][]interface{}
+	source    *Builder
+	conflict  *conflictClause
 	returning []string
 }
 
@@ -29,6 +31,28 @@ func InsertInto(table string) *InsertBuilder {
 	return &InsertBuilder{table: table}
 }
 
+// Clone returns a deep copy of the builder so variants can be derived
+// without affecting the original.
+func (b *InsertBuilder) Clone() *InsertBuilder {
+	c := *b
+	c.columns = cloneSlice(b.columns)
+	c.returning = cloneSlice(b.returning)
+	c.values = make([][]interface{}, len(b.values))
+	for i, row := range b.values {
+		c.values[i] = cloneSlice(row)
+	}
+	if b.source != nil {
+		c.source = b.source.Clone()
+	}
+	if b.conflict != nil {
+		conflict := *b.conflict
+		conflict.target = cloneSlice(b.conflict.target)
+		conflict.update = cloneSlice(b.conflict.update)
+		c.conflict = &conflict
+	}
+	return &c
+}
+
 // Columns sets the columns for the INSERT statement.
 // Example: .Columns("id", "name")
 func (b *InsertBuilder) Columns(cols ...string) *InsertBuilder {
@@ -46,6 +70,21 @@ func (b *InsertBuilder) Values(vals ...interface{}) *InsertBuilder {
 	return b
 }
 
+// FromSelect inserts the rows produced by a SELECT instead of literal values:
+// "INSERT INTO t (cols) SELECT ...". Placeholders are numbered across both
+// parts. Columns are optional; without them the SELECT must match the
+// table's column order.
+//
+// Example:
+//
+//	query.InsertInto("orders_archive").
+//		Columns("id", "amount").
+//		FromSelect(query.From("orders").Select("id", "amount").Where(query.C("created_at").Lt(cutoff)))
+func (b *InsertBuilder) FromSelect(sel *Builder) *InsertBuilder {
+	b.source = sel
+	return b
+}
+
 // Returning specifies columns to return (Postgres style).
 // Example: .Returning("id", "created_at")
 func (b *InsertBuilder) Returning(cols ...string) *InsertBuilder {
@@ -60,27 +99,56 @@ func (b *InsertBuilder) ReturningID() *InsertBuilder {
 
 // ToSQL builds the final INSERT query and returns the SQL string and arguments.
 // Example output: "INSERT INTO users (id, name) VALUES (?, ?) RETURNING id", [1, "John"]
+// It returns an empty string and nil args when the statement is invalid;
+// use SQL to get the reason.
 func (b *InsertBuilder) ToSQL() (string, []interface{}) {
-	if b.table == "" || len(b.columns) == 0 || len(b.values) == 0 {
+	sql, args, err := b.SQL()
+	if err != nil {
 		return "", nil
 	}
+	return sql, args
+}
+
+// SQL builds the final INSERT query with placeholders for the active dialect.
+// It returns an error when the table, columns or values are missing, when a
+// column is listed twice, when a VALUES row does not match the columns, when
+// both VALUES and FromSelect are used, or when an upsert is incomplete.
+func (b *InsertBuilder) SQL() (string, []any, error) {
+	if b.table == "" {
+		return "", nil, ErrEmptyTable
+	}
+	if b.source != nil {
+		return b.selectSQL()
+	}
+	if len(b.columns) == 0 {
+		return "", nil, ErrNoColumns
+	}
+	if len(b.values) == 0 {
+		return "", nil, ErrNoValues
+	}
+	if err := checkDuplicateColumns(b.columns); err != nil {
+		return "", nil, err
+	}
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES ",
 		b.table, strings.Join(b.columns, ", ")))
 
-	var args []interface{}
+	bd := newBinder()
 	var placeholders []string
 
-	for _, row := range b.values {
+	for n, row := range b.values {
+		if len(row) != len(b.columns) {
+			return "", nil, fmt.Errorf("%w: row %d has %d values for %d columns",
+				ErrValueCount, n+1, len(row), len(b.columns))
+		}
 		rowPlaceholders := make([]string, len(row))
 		for i, v := range row {
 			switch v := v.(type) {
 			case RawSQL:
 				rowPlaceholders[i] = string(v) // literal SQL
 			default:
-				rowPlaceholders[i] = "?"
-				args = append(args, v)
+				rowPlaceholders[i] = bd.arg(v)
 			}
 		}
 		placeholders = append(placeholders, fmt.Sprintf("(%s)", strings.Join(rowPlaceholders, ", ")))
@@ -88,10 +156,76 @@ func (b *InsertBuilder) ToSQL() (string, []interface{}) {
 
 	sb.WriteString(strings.Join(placeholders, ", "))
 
+	if err := b.writeConflict(&sb, bd.dialect); err != nil {
+		return "", nil, err
+	}
+
 	if len(b.returning) > 0 {
 		sb.WriteString(" RETURNING ")
 		sb.WriteString(strings.Join(b.returning, ", "))
 	}
 
-	return sb.String(), args
+	return sb.String(), bd.args, nil
+}
+
+// selectSQL builds "INSERT INTO t (cols) SELECT ...".
+func (b *InsertBuilder) selectSQL() (string, []any, error) {
+	if len(b.values) > 0 {
+		return "", nil, fmt.Errorf("%w: INSERT with both VALUES and SELECT", ErrConflictingClause)
+	}
+	if err := checkDuplicateColumns(b.columns); err != nil {
+		return "", nil, err
+	}
+
+	bd := newBinder()
+	sel, err := b.source.render(bd)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(b.table)
+	if len(b.columns) > 0 {
+		sb.WriteString(fmt.Sprintf(" (%s)", strings.Join(b.columns, ", ")))
+	}
+	sb.WriteString(" ")
+	sb.WriteString(sel)
+
+	if err := b.writeConflict(&sb, bd.dialect); err != nil {
+		return "", nil, err
+	}
+
+	if len(b.returning) > 0 {
+		sb.WriteString(" RETURNING ")
+		sb.WriteString(strings.Join(b.returning, ", "))
+	}
+
+	return sb.String(), bd.args, nil
+}
+
+// writeConflict appends the upsert clause, if any.
+func (b *InsertBuilder) writeConflict(sb *strings.Builder, d Dialect) error {
+	if b.conflict == nil {
+		return nil
+	}
+	clause, err := b.conflict.render(d)
+	if err != nil {
+		return err
+	}
+	sb.WriteString(" ")
+	sb.WriteString(clause)
+	return nil
+}
+
+// checkDuplicateColumns reports the first column that appears more than once.
+func checkDuplicateColumns(cols []string) error {
+	seen := make(map[string]struct{}, len(cols))
+	for _, col := range cols {
+		if _, ok := seen[col]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateColumn, col)
+		}
+		seen[col] = struct{}{}
+	}
+	return nil
 }
@@ -69,3 +69,109 @@ func TestInsertEmptyBuilder(t *testing.T) {
 	assert.Equal(t, "", sql)
 	assert.Nil(t, args)
 }
+
+func TestInsertValidation(t *testing.T) {
+	_, _, err := query.InsertInto("").Columns("id").Values(1).SQL()
+	assert.ErrorIs(t, err, query.ErrEmptyTable)
+
+	_, _, err = query.InsertInto("users").Values(1).SQL()
+	assert.ErrorIs(t, err, query.ErrNoColumns)
+
+	_, _, err = query.InsertInto("users").Columns("id").SQL()
+	assert.ErrorIs(t, err, query.ErrNoValues)
+
+	_, _, err = query.InsertInto("users").Columns("id", "name").Values(1, "Alice").Values(2).SQL()
+	assert.ErrorIs(t, err, query.ErrValueCount)
+
+	_, _, err = query.InsertInto("users").Columns("id", "name", "id").Values(1, "Alice", 1).SQL()
+	assert.ErrorIs(t, err, query.ErrDuplicateColumn)
+}
+
+func TestInsertPostgresPlaceholders(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.InsertInto("users").
+		Columns("name", "created_at").
+		Values("Alice", query.Raw("NOW()")).
+		Values("Bob", query.Raw("NOW()")).
+		SQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name, created_at) VALUES ($1, NOW()), ($2, NOW())", sql)
+	assert.Equal(t, []any{"Alice", "Bob"}, args)
+}
+
+func TestInsertFromSelect(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sel := query.From("orders").
+		Select("id", "amount").
+		SelectExpr(query.Expr("?", "archived")).
+		Where(query.C("created_at").Lt("2024-01-01"), query.C("status").Eq("closed")).
+		Limit(1000)
+
+	sql, args, err := query.InsertInto("orders_archive").
+		Columns("id", "amount", "reason").
+		FromSelect(sel).
+		Returning("id").
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO orders_archive (id, amount, reason) "+
+		"SELECT id, amount, $1 FROM orders WHERE created_at < $2 AND status = $3 LIMIT $4 RETURNING id", sql)
+	assert.Equal(t, []any{"archived", "2024-01-01", "closed", 1000}, args)
+
+	sql, _, err = query.InsertInto("orders_archive").FromSelect(query.From("orders")).SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO orders_archive SELECT * FROM orders", sql)
+
+	_, _, err = query.InsertInto("orders_archive").Columns("id").Values(1).FromSelect(sel).SQL()
+	assert.ErrorIs(t, err, query.ErrConflictingClause)
+}
+
+func TestInsertOnConflict(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.InsertInto("users").
+		Columns("id", "name", "email").
+		Values(1, "John", "john@example.com").
+		OnConflict("id").
+		DoUpdate("name", "email").
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id, name, email) VALUES (?, ?, ?) "+
+		"ON DUPLICATE KEY UPDATE name = VALUES(name), email = VALUES(email)", sql)
+	assert.Equal(t, []any{1, "John", "john@example.com"}, args)
+
+	sql, _, err = query.InsertInto("users").Columns("id").Values(1).OnConflict("id").DoNothing().SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id) VALUES (?) ON DUPLICATE KEY UPDATE id = id", sql)
+
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, _, err = query.InsertInto("users").
+		Columns("id", "name").
+		Values(1, "John").
+		OnConflict("id").
+		DoUpdate("name").
+		Returning("id").
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id, name) VALUES ($1, $2) "+
+		"ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name RETURNING id", sql)
+
+	sql, _, err = query.InsertInto("users").Columns("id").Values(1).OnConflict("id").DoNothing().SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id) VALUES ($1) ON CONFLICT (id) DO NOTHING", sql)
+
+	_, _, err = query.InsertInto("users").Columns("id").Values(1).DoUpdate("name").SQL()
+	assert.ErrorIs(t, err, query.ErrMissingClause)
+
+	_, _, err = query.InsertInto("users").Columns("id").Values(1).OnConflict("id").SQL()
+	assert.ErrorIs(t, err, query.ErrMissingClause)
+
+	_, _, err = query.InsertInto("users").Columns("id").Values(1).OnConflict("id").DoUpdate("id").DoNothing().SQL()
+	assert.ErrorIs(t, err, query.ErrConflictingClause)
+}
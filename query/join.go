@@ -1,5 +1,19 @@
 package query
 
+import (
+	"fmt"
+	"strings"
+)
+
+// joinKinds lists the JOIN kinds accepted by JoinKind.
+var joinKinds = map[string]string{
+	"INNER": "INNER JOIN",
+	"LEFT":  "LEFT JOIN",
+	"RIGHT": "RIGHT JOIN",
+	"FULL":  "FULL JOIN",
+	"CROSS": "CROSS JOIN",
+}
+
 // joinClause stores info about a JOIN clause
 type joinClause struct {
 	kind  string
@@ -30,3 +44,23 @@ func (b *Builder) FullJoin(table, on string) *Builder {
 	b.joins = append(b.joins, joinClause{"FULL JOIN", table, on})
 	return b
 }
+
+// JoinKind adds a JOIN clause of the given kind: INNER, LEFT, RIGHT, FULL or CROSS.
+// An unknown kind is reported by SQL as ErrUnknownJoin.
+// Example: .JoinKind("left", "orders", "users.id = orders.user_id")
+func (b *Builder) JoinKind(kind, table, on string) *Builder {
+	b.joins = append(b.joins, joinClause{strings.ToUpper(strings.TrimSpace(kind)), table, on})
+	return b
+}
+
+// render returns the SQL for the join, validating its kind.
+func (j joinClause) render() (string, error) {
+	kind, ok := joinKinds[strings.TrimSuffix(j.kind, " JOIN")]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownJoin, j.kind)
+	}
+	if j.on == "" {
+		return fmt.Sprintf("%s %s", kind, j.table), nil
+	}
+	return fmt.Sprintf("%s %s ON %s", kind, j.table, j.on), nil
+}
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSON returns a column that extracts the text value at path inside a
+// JSON/JSONB column, rendered by the active dialect. All Column operators
+// can be applied to the result.
+//
+// Example:
+//
+//	query.C("meta").JSON("address", "city").Eq("Jakarta")
+//
+//	 Postgres:      "meta->'address'->>'city' = $1"
+//	 MySQL/MariaDB: "JSON_UNQUOTE(JSON_EXTRACT(meta, '$.address.city')) = ?"
+func (c Column) JSON(path ...string) Column {
+	return Column{name: dialectExpr("json", append([]string{c.name}, path...)...)}
+}
+
+// JSONContains creates a condition testing that the JSON column contains doc.
+// Strings and []byte are bound as-is and must already hold JSON; any other
+// value is encoded with encoding/json.
+//
+//	Postgres:      "meta @> ?"
+//	MySQL/MariaDB: "JSON_CONTAINS(meta, ?)"
+func (c Column) JSONContains(doc any) Condition {
+	return Condition{Expr: dialectExpr("jsoncontains", c.name), Args: []any{jsonArg(doc)}}
+}
+
+// JSONHasKey creates a condition testing that the JSON column has a top-level key.
+//
+//	Postgres:      "meta ? $1" (the operator is escaped as "??" in Expr)
+//	MySQL/MariaDB: "JSON_CONTAINS_PATH(meta, 'one', ?)" with "$.key"
+func (c Column) JSONHasKey(key string) Condition {
+	return Condition{
+		Expr: dialectExpr("jsonhaskey", c.name, key),
+		Args: []any{jsonKeyArg{column: c.name, key: key}},
+	}
+}
+
+// jsonArg encodes doc as a JSON string unless it already is one.
+func jsonArg(doc any) any {
+	switch v := doc.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		// leave the value untouched and let the driver report it
+		return doc
+	}
+	return string(b)
+}
+
+// jsonPath builds a MySQL JSON path such as $.address.city or $.tags[0].
+func jsonPath(path []string) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, p := range path {
+		switch {
+		case isIndex(p):
+			sb.WriteString(fmt.Sprintf("[%s]", p))
+		case isIdentifier(p):
+			sb.WriteString("." + p)
+		default:
+			sb.WriteString(fmt.Sprintf(".%q", p))
+		}
+	}
+	return sb.String()
+}
+
+// quoteLiteral renders s as a single-quoted SQL string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func isIndex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
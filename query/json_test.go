@@ -0,0 +1,89 @@
+package query_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONOperators_Postgres(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	expr, args := where(t, query.C("meta").JSON("address", "city").Eq("Jakarta"))
+	assert.Equal(t, "meta->'address'->>'city' = $1", expr)
+	assert.Equal(t, []any{"Jakarta"}, args)
+
+	expr, _ = where(t, query.C("meta").JSON("tags", "0").Eq("go"))
+	assert.Equal(t, "meta->'tags'->>0 = $1", expr)
+
+	expr, _ = where(t, query.C("meta").JSON("it's").IsNull())
+	assert.Equal(t, "meta->>'it''s' IS NULL", expr)
+
+	expr, args = where(t, query.C("meta").JSONContains(map[string]any{"plan": "pro"}))
+	assert.Equal(t, "meta @> $1", expr)
+	assert.Equal(t, []any{`{"plan":"pro"}`}, args)
+
+	expr, args = where(t, query.C("meta").JSONHasKey("address"))
+	assert.Equal(t, "meta ? $1", expr)
+	assert.Equal(t, []any{"address"}, args)
+
+	sql, args, err := query.From("users").
+		Select("id").
+		Where(query.C("meta").JSONHasKey("address")).
+		Where(query.C("meta").JSON("address", "city").Eq("Jakarta")).
+		Where(query.C("meta").JSONContains(`{"plan":"pro"}`)).
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE meta ? $1 AND meta->'address'->>'city' = $2 AND meta @> $3", sql)
+	assert.Equal(t, []any{"address", "Jakarta", `{"plan":"pro"}`}, args)
+}
+
+func TestJSONOperators_MySQL(t *testing.T) {
+	for _, d := range []query.Dialect{query.MySQLDialect{}, query.MariaDBDialect{}} {
+		query.SetDialect(d)
+
+		expr, args := where(t, query.C("meta").JSON("address", "city").Eq("Jakarta"))
+		assert.Equal(t, "JSON_UNQUOTE(JSON_EXTRACT(meta, '$.address.city')) = ?", expr)
+		assert.Equal(t, []any{"Jakarta"}, args)
+
+		expr, _ = where(t, query.C("meta").JSON("tags", "0", "first name").Eq("go"))
+		assert.Equal(t, `JSON_UNQUOTE(JSON_EXTRACT(meta, '$.tags[0]."first name"')) = ?`, expr)
+
+		expr, args = where(t, query.C("meta").JSONContains([]int{1, 2}))
+		assert.Equal(t, "JSON_CONTAINS(meta, ?)", expr)
+		assert.Equal(t, []any{"[1,2]"}, args)
+
+		expr, args = where(t, query.C("meta").JSONHasKey("address"))
+		assert.Equal(t, "JSON_CONTAINS_PATH(meta, 'one', ?)", expr)
+		assert.Equal(t, []any{"$.address"}, args)
+	}
+	query.SetDialect(query.MySQLDialect{})
+}
+
+// where renders cond as the WHERE clause of a SELECT for the active dialect.
+func where(t *testing.T, cond query.Condition) (string, []any) {
+	t.Helper()
+	sql, args, err := query.From("t").Where(cond).SQL()
+	assert.NoError(t, err)
+	return strings.TrimPrefix(sql, "SELECT * FROM t WHERE "), args
+}
+
+func TestJSONOperators_ResolvedAtBuildTime(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+	cond := query.C("meta").JSON("name").ILike("%go%")
+	hasKey := query.C("meta").JSONHasKey("name")
+
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	expr, args := where(t, cond)
+	assert.Equal(t, "meta->>'name' ILIKE $1", expr)
+	assert.Equal(t, []any{"%go%"}, args)
+
+	expr, args = where(t, hasKey)
+	assert.Equal(t, "meta ? $1", expr)
+	assert.Equal(t, []any{"name"}, args)
+}
@@ -0,0 +1,70 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_ForUpdateSkipLocked(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.From("jobs").
+		Select("id").
+		Where(query.C("status").Eq("pending")).
+		OrderBy("id").
+		Limit(10).
+		ForUpdate().
+		SkipLocked().
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM jobs WHERE status = $1 ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED", sql)
+	assert.Equal(t, []any{"pending", 10}, args)
+}
+
+func TestBuilder_LockVariants(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	tests := []struct {
+		name string
+		b    *query.Builder
+		want string
+	}{
+		{"for update", query.From("t").ForUpdate(), "SELECT * FROM t FOR UPDATE"},
+		{"for share", query.From("t").ForShare(), "SELECT * FROM t FOR SHARE"},
+		{"nowait", query.From("t").ForUpdate().NoWait(), "SELECT * FROM t FOR UPDATE NOWAIT"},
+		{"of", query.From("orders o").Join("customers c", "c.id = o.customer_id").ForUpdate().Of("o"), "SELECT * FROM orders o INNER JOIN customers c ON c.id = o.customer_id FOR UPDATE OF o"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _, err := tt.b.SQL()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, sql)
+			assert.True(t, tt.b.Locking())
+		})
+	}
+}
+
+func TestBuilder_LockValidation(t *testing.T) {
+	query.SetDialect(query.MariaDBDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	_, _, err := query.From("t").SkipLocked().SQL()
+	assert.ErrorIs(t, err, query.ErrMissingClause)
+
+	_, _, err = query.From("t").ForShare().SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+
+	_, _, err = query.From("t").ForUpdate().Of("t").SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+
+	sql, _, err := query.From("t").ForUpdate().SkipLocked().SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t FOR UPDATE SKIP LOCKED", sql)
+	assert.False(t, query.From("t").Locking())
+
+	_, _, err = query.From("t").ForUpdate().ForShare().SQL()
+	assert.ErrorIs(t, err, query.ErrConflictingClause)
+}
@@ -0,0 +1,214 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergeBuilder builds SQL MERGE statements (Postgres 15+).
+type MergeBuilder struct {
+	target  string
+	source  string
+	sel     *Builder
+	on      []Condition
+	clauses []mergeClause
+}
+
+// mergeClause stores a single WHEN [NOT] MATCHED THEN ... action.
+type mergeClause struct {
+	matched bool
+	action  string // UPDATE, DELETE or INSERT
+	columns []string
+	values  []Condition
+}
+
+// Merge creates a new MergeBuilder for the target table.
+// Only dialects supporting FeatureMerge (Postgres 15+) can build it; other
+// dialects get an error wrapping ErrUnsupported.
+//
+// Example:
+//
+//	q := query.Merge("inventory AS i").
+//		Using("shipments AS s").
+//		On(query.Expr("i.sku = s.sku")).
+//		WhenMatchedUpdate(map[string]any{"qty": query.Raw("i.qty + s.qty")}).
+//		WhenNotMatchedInsert(map[string]any{"sku": query.C("s.sku"), "qty": query.C("s.qty")})
+//
+//	sql, args, err := q.SQL()
+//	 "MERGE INTO inventory AS i USING shipments AS s ON i.sku = s.sku
+//	  WHEN MATCHED THEN UPDATE SET qty = i.qty + s.qty
+//	  WHEN NOT MATCHED THEN INSERT (qty, sku) VALUES (s.qty, s.sku)"
+func Merge(target string) *MergeBuilder {
+	return &MergeBuilder{target: target}
+}
+
+// Clone returns a deep copy of the builder so variants can be derived
+// without affecting the original.
+func (b *MergeBuilder) Clone() *MergeBuilder {
+	c := *b
+	c.on = cloneSlice(b.on)
+	c.clauses = cloneSlice(b.clauses)
+	if b.sel != nil {
+		c.sel = b.sel.Clone()
+	}
+	return &c
+}
+
+// Using sets the source table (optionally with an alias, "shipments AS s").
+func (b *MergeBuilder) Using(source string) *MergeBuilder {
+	b.source = source
+	b.sel = nil
+	return b
+}
+
+// UsingSelect uses a subquery as the source: "USING (SELECT ...) AS alias".
+// Placeholders are numbered across the subquery and the rest of the statement.
+func (b *MergeBuilder) UsingSelect(sel *Builder, alias string) *MergeBuilder {
+	b.sel = sel
+	b.source = alias
+	return b
+}
+
+// On sets the join condition between target and source.
+// Multiple conditions are joined with AND.
+func (b *MergeBuilder) On(conds ...Condition) *MergeBuilder {
+	b.on = append(b.on, conds...)
+	return b
+}
+
+// WhenMatchedUpdate adds "WHEN MATCHED THEN UPDATE SET ...".
+// Columns are applied in sorted order; RawSQL, Column and Condition values
+// are inlined, anything else is bound.
+func (b *MergeBuilder) WhenMatchedUpdate(set map[string]any) *MergeBuilder {
+	cols, vals := sortedAssignments(set)
+	b.clauses = append(b.clauses, mergeClause{matched: true, action: "UPDATE", columns: cols, values: vals})
+	return b
+}
+
+// WhenMatchedDelete adds "WHEN MATCHED THEN DELETE".
+func (b *MergeBuilder) WhenMatchedDelete() *MergeBuilder {
+	b.clauses = append(b.clauses, mergeClause{matched: true, action: "DELETE"})
+	return b
+}
+
+// WhenNotMatchedInsert adds "WHEN NOT MATCHED THEN INSERT (...) VALUES (...)".
+// Columns are applied in sorted order; values are rendered as in WhenMatchedUpdate.
+func (b *MergeBuilder) WhenNotMatchedInsert(values map[string]any) *MergeBuilder {
+	cols, vals := sortedAssignments(values)
+	b.clauses = append(b.clauses, mergeClause{action: "INSERT", columns: cols, values: vals})
+	return b
+}
+
+// Build assembles the MERGE statement and returns it with args.
+// It returns an empty string and nil args when the statement is invalid;
+// use SQL to get the reason.
+func (b *MergeBuilder) Build() (string, []any) {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return "", nil
+	}
+	return sql, args
+}
+
+// SQL assembles the MERGE statement and returns it with args. It returns an
+// error when the dialect does not support MERGE, when the target, source,
+// ON condition or WHEN clauses are missing, or when a condition's
+// placeholders do not match its arguments.
+func (b *MergeBuilder) SQL() (string, []any, error) {
+	bd := newBinder()
+	if !Supports(bd.dialect, FeatureMerge) {
+		return "", nil, unsupported(bd.dialect, "MERGE")
+	}
+	if b.target == "" {
+		return "", nil, ErrEmptyTable
+	}
+	if b.source == "" || len(b.on) == 0 {
+		return "", nil, fmt.Errorf("%w: MERGE requires USING and ON", ErrMissingClause)
+	}
+	if len(b.clauses) == 0 {
+		return "", nil, fmt.Errorf("%w: MERGE requires at least one WHEN clause", ErrMissingClause)
+	}
+
+	var sql strings.Builder
+	sql.WriteString("MERGE INTO ")
+	sql.WriteString(b.target)
+	sql.WriteString(" USING ")
+	if b.sel != nil {
+		sel, err := b.sel.render(bd)
+		if err != nil {
+			return "", nil, err
+		}
+		sql.WriteString(fmt.Sprintf("(%s) AS %s", sel, b.source))
+	} else {
+		sql.WriteString(b.source)
+	}
+
+	on, err := bd.list(b.on, " AND ")
+	if err != nil {
+		return "", nil, err
+	}
+	sql.WriteString(" ON ")
+	sql.WriteString(on)
+
+	for _, c := range b.clauses {
+		clause, err := c.render(bd)
+		if err != nil {
+			return "", nil, err
+		}
+		sql.WriteString(" ")
+		sql.WriteString(clause)
+	}
+
+	return sql.String(), bd.args, nil
+}
+
+// render writes a single WHEN clause using bd.
+func (c mergeClause) render(bd *binder) (string, error) {
+	when := "WHEN NOT MATCHED THEN "
+	if c.matched {
+		when = "WHEN MATCHED THEN "
+	}
+	if c.action == "DELETE" {
+		return when + "DELETE", nil
+	}
+	if len(c.columns) == 0 {
+		return "", ErrNoColumns
+	}
+
+	vals := make([]string, len(c.values))
+	for i, v := range c.values {
+		expr, err := bd.bind(v)
+		if err != nil {
+			return "", err
+		}
+		vals[i] = expr
+	}
+
+	if c.action == "UPDATE" {
+		sets := make([]string, len(c.columns))
+		for i, col := range c.columns {
+			sets[i] = fmt.Sprintf("%s = %s", col, vals[i])
+		}
+		return when + "UPDATE SET " + strings.Join(sets, ", "), nil
+	}
+	return fmt.Sprintf("%sINSERT (%s) VALUES (%s)", when,
+		strings.Join(c.columns, ", "), strings.Join(vals, ", ")), nil
+}
+
+// sortedAssignments returns the columns of values in sorted order with
+// their values rendered through operand.
+func sortedAssignments(values map[string]any) ([]string, []Condition) {
+	cols := make([]string, 0, len(values))
+	for col := range values {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	vals := make([]Condition, len(cols))
+	for i, col := range cols {
+		expr, args := operand(values[col])
+		vals[i] = Condition{Expr: expr, Args: args}
+	}
+	return cols, vals
+}
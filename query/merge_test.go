@@ -0,0 +1,57 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeBuilder_Postgres(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.Merge("inventory AS i").
+		Using("shipments AS s").
+		On(query.Expr("i.sku = s.sku")).
+		WhenMatchedUpdate(map[string]any{"qty": query.Raw("i.qty + s.qty"), "updated_by": "sync"}).
+		WhenNotMatchedInsert(map[string]any{"sku": query.C("s.sku"), "qty": query.C("s.qty"), "source": "sync"}).
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO inventory AS i USING shipments AS s ON i.sku = s.sku "+
+		"WHEN MATCHED THEN UPDATE SET qty = i.qty + s.qty, updated_by = $1 "+
+		"WHEN NOT MATCHED THEN INSERT (qty, sku, source) VALUES (s.qty, s.sku, $2)", sql)
+	assert.Equal(t, []any{"sync", "sync"}, args)
+
+	sel := query.From("staging").Select("sku", "qty").Where(query.C("batch").Eq(9))
+	sql, args, err = query.Merge("inventory AS i").
+		UsingSelect(sel, "s").
+		On(query.Expr("i.sku = s.sku"), query.C("i.warehouse").Eq("JKT")).
+		WhenMatchedDelete().
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "MERGE INTO inventory AS i USING (SELECT sku, qty FROM staging WHERE batch = $1) AS s "+
+		"ON i.sku = s.sku AND i.warehouse = $2 WHEN MATCHED THEN DELETE", sql)
+	assert.Equal(t, []any{9, "JKT"}, args)
+}
+
+func TestMergeBuilder_Validation(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+	_, _, err := query.Merge("t").Using("s").On(query.Expr("t.id = s.id")).WhenMatchedDelete().SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	_, _, err = query.Merge("").Using("s").On(query.Expr("t.id = s.id")).WhenMatchedDelete().SQL()
+	assert.ErrorIs(t, err, query.ErrEmptyTable)
+
+	_, _, err = query.Merge("t").Using("s").WhenMatchedDelete().SQL()
+	assert.ErrorIs(t, err, query.ErrMissingClause)
+
+	_, _, err = query.Merge("t").Using("s").On(query.Expr("t.id = s.id")).SQL()
+	assert.ErrorIs(t, err, query.ErrMissingClause)
+
+	_, _, err = query.Merge("t").Using("s").On(query.Expr("t.id = s.id")).WhenMatchedUpdate(nil).SQL()
+	assert.ErrorIs(t, err, query.ErrNoColumns)
+}
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// Named holds values for named parameters (":name") in Expr.
+type Named map[string]any
+
+// namedKey identifies a named parameter within a single Expr call, so the
+// same name used by two different expressions is never merged.
+type namedKey struct {
+	scope uint64
+	name  string
+}
+
+// namedArg is the argument stored in Condition.Args for a named parameter.
+// The binder unwraps it when the statement is built.
+type namedArg struct {
+	key   namedKey
+	value any
+}
+
+// missingParam marks a named parameter without a value. Building a
+// statement that contains it fails with ErrMissingParam.
+type missingParam struct {
+	name string
+}
+
+var namedScopes atomic.Uint64
+
+// Expr creates a condition from a raw SQL expression.
+//
+// Positional arguments bind to "?" markers in order. When the only argument
+// is a Named map, ":name" markers are bound by name instead; use NamedFrom to
+// bind the fields of a struct. Any other value, including a plain map or a
+// struct, is a positional argument. A parameter used several times is bound
+// once per use with "?" placeholders and shares a single "$n" with numbered
+// ones.
+//
+// Example:
+//
+//	query.Expr("created_at BETWEEN :from AND :to", query.Named{"from": a, "to": b})
+//
+//	 MySQL:    "created_at BETWEEN ? AND ?"
+//	 Postgres: "created_at BETWEEN $1 AND $2"
+//
+// Casts ("::date") and markers inside quoted strings or comments are left alone.
+func Expr(sql string, args ...any) Condition {
+	if len(args) == 1 {
+		if params, ok := args[0].(Named); ok {
+			return bindNamed(sql, params)
+		}
+	}
+	return Condition{Expr: sql, Args: args}
+}
+
+// bindNamed converts ":name" markers in sql to "?" and collects their values.
+func bindNamed(sql string, params map[string]any) Condition {
+	scope := namedScopes.Add(1)
+	var args []any
+
+	expr := lexSQL(GetDialect(), sql, func(i int) (string, int) {
+		if sql[i] != ':' || (i > 0 && sql[i-1] == ':') {
+			return "", 0
+		}
+		j := i + 1
+		for j < len(sql) && (sql[j] == '_' || isLetter(sql[j]) || (j > i+1 && isDigit(sql[j]))) {
+			j++
+		}
+		if j == i+1 {
+			return "", 0
+		}
+		name := sql[i+1 : j]
+		if v, ok := params[name]; ok {
+			args = append(args, namedArg{key: namedKey{scope: scope, name: name}, value: v})
+		} else {
+			args = append(args, missingParam{name: name})
+		}
+		return "?", j - i
+	})
+
+	return Condition{Expr: expr, Args: args}
+}
+
+// NamedFrom returns the `db` tagged exported fields of a struct (or pointer
+// to struct) as Named parameters. It returns nil for any other value.
+//
+// Example:
+//
+//	query.Expr("expires_at BETWEEN :from AND :to", query.NamedFrom(window))
+func NamedFrom(v any) Named {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	params := Named{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := strings.Split(sf.Tag.Get("db"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		params[name] = rv.Field(i).Interface()
+	}
+	return params
+}
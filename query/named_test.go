@@ -0,0 +1,105 @@
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpr_Positional(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	now := time.Now()
+	sql, args, err := query.From("events").
+		Where(query.Expr("created_at > ? AND kind = ?", now, "login")).
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM events WHERE created_at > $1 AND kind = $2", sql)
+	assert.Equal(t, []any{now, "login"}, args)
+}
+
+func TestExpr_Named(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	cond := query.Expr("(created_at BETWEEN :from AND :to OR updated_at BETWEEN :from AND :to) AND note <> ':skip' AND day = :from::date",
+		query.Named{"from": from, "to": to})
+
+	build := func() (string, []any, error) {
+		return query.From("events").
+			Select("id").
+			Where(query.C("tenant_id").Eq(7), cond).
+			SQL()
+	}
+
+	query.SetDialect(query.MySQLDialect{})
+	sql, args, err := build()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM events WHERE tenant_id = ? AND "+
+		"(created_at BETWEEN ? AND ? OR updated_at BETWEEN ? AND ?) AND note <> ':skip' AND day = ?::date", sql)
+	assert.Equal(t, []any{7, from, to, from, to, from}, args)
+
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+	sql, args, err = build()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM events WHERE tenant_id = $1 AND "+
+		"(created_at BETWEEN $2 AND $3 OR updated_at BETWEEN $2 AND $3) AND note <> ':skip' AND day = $2::date", sql)
+	assert.Equal(t, []any{7, from, to}, args)
+}
+
+func TestExpr_NamedScopesAreIndependent(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.From("t").
+		Where(query.Expr("a = :v", query.Named{"v": 1}), query.Expr("b = :v", query.Named{"v": 2})).
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE a = $1 AND b = $2", sql)
+	assert.Equal(t, []any{1, 2}, args)
+}
+
+func TestExpr_Struct(t *testing.T) {
+	type window struct {
+		From   time.Time `db:"from"`
+		To     time.Time `db:"to"`
+		Ignore string
+	}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	query.SetDialect(query.MySQLDialect{})
+	sql, args, err := query.Delete("sessions").
+		Where(query.Expr("expires_at BETWEEN :from AND :to", query.NamedFrom(&window{From: from, To: to}))).
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM sessions WHERE expires_at BETWEEN ? AND ?", sql)
+	assert.Equal(t, []any{from, to}, args)
+}
+
+func TestExpr_PositionalMapAndStruct(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	doc := map[string]any{"plan": "pro"}
+	sql, args, err := query.From("users").Where(query.Expr("JSON_CONTAINS(meta, ?)", doc)).SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE JSON_CONTAINS(meta, ?)", sql)
+	assert.Equal(t, []any{doc}, args)
+
+	type point struct {
+		X int `db:"x"`
+	}
+	_, args, err = query.From("t").Where(query.Expr("p = ?", point{X: 1})).SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, []any{point{X: 1}}, args)
+}
+
+func TestExpr_MissingParam(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	_, _, err := query.From("t").Where(query.Expr("a = :a AND b = :b", query.Named{"a": 1})).SQL()
+	assert.ErrorIs(t, err, query.ErrMissingParam)
+}
@@ -76,7 +76,11 @@ func (c Column) IsNotNull() Condition {
 //
 
 // In creates a condition: "column IN (?, ?, ...)".
+// With no values it renders the constant-false predicate "1 = 0".
 func (c Column) In(vals ...any) Condition {
+	if len(vals) == 0 {
+		return Condition{Expr: "1 = 0"}
+	}
 	placeholders := strings.TrimRight(strings.Repeat("?,", len(vals)), ",")
 	return Condition{
 		Expr: fmt.Sprintf("%s IN (%s)", c.name, placeholders),
@@ -85,7 +89,11 @@ func (c Column) In(vals ...any) Condition {
 }
 
 // NotIn creates a condition: "column NOT IN (?, ?, ...)".
+// With no values it renders the constant-true predicate "1 = 1".
 func (c Column) NotIn(vals ...any) Condition {
+	if len(vals) == 0 {
+		return Condition{Expr: "1 = 1"}
+	}
 	placeholders := strings.TrimRight(strings.Repeat("?,", len(vals)), ",")
 	return Condition{
 		Expr: fmt.Sprintf("%s NOT IN (%s)", c.name, placeholders),
@@ -108,6 +116,35 @@ func (c Column) NotLike(pattern string) Condition {
 	return Condition{Expr: fmt.Sprintf("%s NOT LIKE ?", c.name), Args: []any{pattern}}
 }
 
+// ILike creates a case-insensitive LIKE condition rendered by the active dialect.
+// Postgres: "column ILIKE ?", MySQL/MariaDB and dialects without a native
+// form: "LOWER(column) LIKE LOWER(?)".
+func (c Column) ILike(pattern string) Condition {
+	return Condition{Expr: dialectExpr("ilike", c.name), Args: []any{pattern}}
+}
+
+// Regexp creates a regular expression match rendered by the active dialect.
+// Postgres: "column ~ ?", MySQL/MariaDB: "column REGEXP ?".
+func (c Column) Regexp(pattern string) Condition {
+	return Condition{Expr: dialectExpr("regexp", c.name), Args: []any{pattern}}
+}
+
+// FullText creates a full-text search condition on the column.
+// See the package-level FullText for the rendered SQL.
+func (c Column) FullText(term string) Condition {
+	return FullText(term, c.name)
+}
+
+// FullText creates a full-text search condition over one or more columns,
+// rendered by the active dialect. Building it without columns fails with
+// ErrNoColumns.
+//
+//	Postgres:      "to_tsvector(title) @@ plainto_tsquery(?)"
+//	MySQL/MariaDB: "MATCH(title, body) AGAINST(? IN BOOLEAN MODE)"
+func FullText(term string, cols ...string) Condition {
+	return Condition{Expr: dialectExpr("fulltext", cols...), Args: []any{term}}
+}
+
 //
 // --- Range matching ---
 //
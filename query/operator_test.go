@@ -108,3 +108,98 @@ func TestNestedLogicalOperators(t *testing.T) {
 	assert.Equal(t, "NOT (age > ? AND status = ?)", cond2.Expr)
 	assert.Equal(t, []any{18, "active"}, cond2.Args)
 }
+
+func TestTextSearchOperators(t *testing.T) {
+	defer query.SetDialect(query.MySQLDialect{})
+
+	tests := []struct {
+		name     string
+		dialect  query.Dialect
+		ilike    string
+		regexp   string
+		fullText string
+		multi    string
+	}{
+		{
+			name:     "MySQL",
+			dialect:  query.MySQLDialect{},
+			ilike:    "LOWER(name) LIKE LOWER(?)",
+			regexp:   "name REGEXP ?",
+			fullText: "MATCH(body) AGAINST(? IN BOOLEAN MODE)",
+			multi:    "MATCH(title, body) AGAINST(? IN BOOLEAN MODE)",
+		},
+		{
+			name:     "MariaDB",
+			dialect:  query.MariaDBDialect{},
+			ilike:    "LOWER(name) LIKE LOWER(?)",
+			regexp:   "name REGEXP ?",
+			fullText: "MATCH(body) AGAINST(? IN BOOLEAN MODE)",
+			multi:    "MATCH(title, body) AGAINST(? IN BOOLEAN MODE)",
+		},
+		{
+			name:     "Postgres",
+			dialect:  query.PostgresDialect{},
+			ilike:    "name ILIKE $1",
+			regexp:   "name ~ $1",
+			fullText: "to_tsvector(body) @@ plainto_tsquery($1)",
+			multi:    "to_tsvector(concat_ws(' ', title, body)) @@ plainto_tsquery($1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query.SetDialect(tt.dialect)
+
+			expr, args := where(t, query.C("name").ILike("%john%"))
+			assert.Equal(t, tt.ilike, expr)
+			assert.Equal(t, []any{"%john%"}, args)
+
+			expr, args = where(t, query.C("name").Regexp("^jo"))
+			assert.Equal(t, tt.regexp, expr)
+			assert.Equal(t, []any{"^jo"}, args)
+
+			expr, args = where(t, query.C("body").FullText("go orm"))
+			assert.Equal(t, tt.fullText, expr)
+			assert.Equal(t, []any{"go orm"}, args)
+
+			expr, args = where(t, query.FullText("go orm", "title", "body"))
+			assert.Equal(t, tt.multi, expr)
+			assert.Equal(t, []any{"go orm"}, args)
+
+			_, _, err := query.From("posts").Where(query.FullText("go orm")).SQL()
+			assert.ErrorIs(t, err, query.ErrNoColumns)
+		})
+	}
+}
+
+// plainDialect implements only the required Dialect methods.
+type plainDialect struct{}
+
+func (plainDialect) Placeholder(int) string { return "?" }
+func (plainDialect) Name() string           { return "plain" }
+
+func TestMinimalDialect(t *testing.T) {
+	query.SetDialect(plainDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.From("users").Where(query.C("id").Eq(1)).SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = ?", sql)
+	assert.Equal(t, []any{1}, args)
+
+	sql, args, err = query.From("users").Where(query.C("name").ILike("a%")).SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE LOWER(name) LIKE LOWER(?)", sql)
+	assert.Equal(t, []any{"a%"}, args)
+
+	_, _, err = query.From("users").Where(query.C("name").Regexp("^a")).SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+	_, _, err = query.From("users").Where(query.FullText("a", "title")).SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+
+	_, _, err = query.From("users").ForShare().SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+
+	_, _, err = query.AdvisoryLock("job").SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+}
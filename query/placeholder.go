@@ -0,0 +1,236 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// binder numbers placeholders and collects arguments while a single
+// statement is rendered. It keeps that state out of the builders, so
+// building is free of side effects and can run repeatedly or concurrently.
+type binder struct {
+	dialect  Dialect
+	numbered bool
+	index    int
+	args     []any
+	named    map[namedKey]string
+	err      error
+}
+
+// newBinder returns a binder for the active dialect.
+func newBinder() *binder {
+	d := GetDialect()
+	return &binder{
+		dialect:  d,
+		numbered: d.Placeholder(1) != d.Placeholder(2),
+	}
+}
+
+// bind rewrites the markers of cond for the dialect and collects its
+// arguments, checking that the number of markers matches len(cond.Args).
+func (bd *binder) bind(cond Condition) (string, error) {
+	expr, err := resolveDialectExprs(bd.dialect, cond.Expr)
+	if err != nil {
+		return "", err
+	}
+	n := 0
+	expr = rewritePlaceholders(bd.dialect, expr, func() string {
+		n++
+		if n > len(cond.Args) {
+			return "?"
+		}
+		return bd.arg(cond.Args[n-1])
+	})
+	if n != len(cond.Args) {
+		return "", fmt.Errorf("%w: %q has %d placeholders but %d args",
+			ErrPlaceholderMismatch, cond.Expr, n, len(cond.Args))
+	}
+	if bd.err != nil {
+		return "", bd.err
+	}
+	return expr, nil
+}
+
+// list binds conds in order and joins them with sep.
+func (bd *binder) list(conds []Condition, sep string) (string, error) {
+	parts := make([]string, len(conds))
+	for i, cond := range conds {
+		expr, err := bd.bind(cond)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = expr
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// arg binds a single value and returns its placeholder.
+// A named parameter that was already bound in this statement reuses its
+// placeholder on dialects with numbered placeholders ($n), and is bound
+// again on dialects with anonymous ones (?).
+func (bd *binder) arg(v any) string {
+	switch v := v.(type) {
+	case missingParam:
+		if bd.err == nil {
+			bd.err = fmt.Errorf("%w: %s", ErrMissingParam, v.name)
+		}
+		return "?"
+	case jsonKeyArg:
+		if jd, ok := bd.dialect.(JSONDialect); ok {
+			_, arg := jd.JSONHasKey(v.column, v.key)
+			return bd.arg(arg)
+		}
+	case namedArg:
+		if !bd.numbered {
+			return bd.arg(v.value)
+		}
+		if p, ok := bd.named[v.key]; ok {
+			return p
+		}
+		p := bd.arg(v.value)
+		if bd.named == nil {
+			bd.named = map[namedKey]string{}
+		}
+		bd.named[v.key] = p
+		return p
+	}
+
+	bd.index++
+	bd.args = append(bd.args, v)
+	return bd.dialect.Placeholder(bd.index)
+}
+
+// rewritePlaceholders replaces every bind marker "?" in expr with the text
+// returned by marker.
+//
+// Quoted strings, quoted identifiers, comments and Postgres dollar-quoted
+// bodies are copied verbatim. A doubled "??" is an escaped literal "?", and
+// on Postgres the JSONB operators "?|" and "?&" are left untouched.
+func rewritePlaceholders(d Dialect, expr string, marker func() string) string {
+	if !strings.Contains(expr, "?") {
+		return expr
+	}
+	_, pg := d.(PostgresDialect)
+
+	return lexSQL(d, expr, func(i int) (string, int) {
+		if expr[i] != '?' {
+			return "", 0
+		}
+		next := byte(0)
+		if i+1 < len(expr) {
+			next = expr[i+1]
+		}
+		switch {
+		case next == '?':
+			return "?", 2
+		case pg && (next == '&' || (next == '|' && (i+2 >= len(expr) || expr[i+2] != '|'))):
+			return expr[i : i+2], 2
+		default:
+			return marker(), 1
+		}
+	})
+}
+
+// lexSQL copies expr while skipping quoted strings, quoted identifiers,
+// comments and Postgres dollar-quoted bodies. Every other position is
+// offered to code, which returns the text to emit and the number of bytes it
+// consumed; consuming 0 bytes copies the current byte unchanged.
+func lexSQL(d Dialect, expr string, code func(i int) (string, int)) string {
+	_, pg := d.(PostgresDialect)
+
+	var sb strings.Builder
+	for i := 0; i < len(expr); {
+		ch := expr[i]
+		next := byte(0)
+		if i+1 < len(expr) {
+			next = expr[i+1]
+		}
+
+		switch {
+		case ch == '\'':
+			// MySQL always honours backslash escapes, Postgres only in E'...' strings
+			backslash := !pg || (i > 0 && (expr[i-1] == 'E' || expr[i-1] == 'e'))
+			j := skipQuoted(expr, i, '\'', backslash)
+			sb.WriteString(expr[i:j])
+			i = j
+		case ch == '"' || ch == '`':
+			j := skipQuoted(expr, i, ch, false)
+			sb.WriteString(expr[i:j])
+			i = j
+		case ch == '-' && next == '-':
+			j := strings.IndexByte(expr[i:], '\n')
+			if j < 0 {
+				j = len(expr) - i
+			}
+			sb.WriteString(expr[i : i+j])
+			i += j
+		case ch == '/' && next == '*':
+			j := strings.Index(expr[i+2:], "*/")
+			end := len(expr)
+			if j >= 0 {
+				end = i + 2 + j + 2
+			}
+			sb.WriteString(expr[i:end])
+			i = end
+		case ch == '$' && pg:
+			j := skipDollarQuoted(expr, i)
+			sb.WriteString(expr[i:j])
+			i = j
+		default:
+			if out, n := code(i); n > 0 {
+				sb.WriteString(out)
+				i += n
+				continue
+			}
+			sb.WriteByte(ch)
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// skipQuoted returns the index just past the quoted token starting at i.
+// A doubled quote character is an escaped quote; when backslash is true a
+// backslash escapes the following character. Unterminated tokens run to the end.
+func skipQuoted(s string, i int, quote byte, backslash bool) int {
+	for j := i + 1; j < len(s); j++ {
+		switch {
+		case backslash && s[j] == '\\':
+			j++
+		case s[j] == quote:
+			if j+1 < len(s) && s[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(s)
+}
+
+// skipDollarQuoted returns the index just past a Postgres dollar-quoted
+// string ($$...$$ or $tag$...$tag$) starting at i. If s[i:] does not open
+// one (e.g. it is a $1 placeholder), it returns i+1.
+func skipDollarQuoted(s string, i int) int {
+	j := i + 1
+	for j < len(s) && (s[j] == '_' || isLetter(s[j]) || (j > i+1 && isDigit(s[j]))) {
+		j++
+	}
+	if j >= len(s) || s[j] != '$' {
+		return i + 1
+	}
+	tag := s[i : j+1]
+	end := strings.Index(s[j+1:], tag)
+	if end < 0 {
+		return len(s)
+	}
+	return j + 1 + end + len(tag)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,72 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceholders_SkipLiteralsAndOperators(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	tests := []struct {
+		name string
+		cond query.Condition
+		want string
+	}{
+		{"string literal", query.Condition{Expr: "note = 'why?' AND id = ?", Args: []any{1}}, "note = 'why?' AND id = $1"},
+		{"escaped quote", query.Condition{Expr: "note = 'it''s?' AND id = ?", Args: []any{1}}, "note = 'it''s?' AND id = $1"},
+		{"E string", query.Condition{Expr: `note = E'\'?' AND id = ?`, Args: []any{1}}, `note = E'\'?' AND id = $1`},
+		{"quoted identifier", query.Condition{Expr: `"what?" = ?`, Args: []any{1}}, `"what?" = $1`},
+		{"line comment", query.Condition{Expr: "id = ? -- really?\n", Args: []any{1}}, "id = $1 -- really?\n"},
+		{"block comment", query.Condition{Expr: "id = /* ? */ ?", Args: []any{1}}, "id = /* ? */ $1"},
+		{"dollar quoted", query.Condition{Expr: "body = $tag$ ? $tag$ AND id = ?", Args: []any{1}}, "body = $tag$ ? $tag$ AND id = $1"},
+		{"escaped marker", query.Condition{Expr: "meta ?? ?", Args: []any{"k"}}, "meta ? $1"},
+		{"jsonb any", query.Condition{Expr: "meta ?| ?", Args: []any{"{a,b}"}}, "meta ?| $1"},
+		{"jsonb all", query.Condition{Expr: "meta ?& ?", Args: []any{"{a,b}"}}, "meta ?& $1"},
+		{"concat", query.Condition{Expr: "name = ?|| 'x'", Args: []any{"a"}}, "name = $1|| 'x'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := query.From("t").Select("id").Where(tt.cond).SQL()
+			assert.NoError(t, err)
+			assert.Equal(t, "SELECT id FROM t WHERE "+tt.want, sql)
+			assert.Equal(t, tt.cond.Args, args)
+		})
+	}
+}
+
+func TestPlaceholders_MySQLBackslashEscapes(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.Delete("t").Where(query.Condition{Expr: `note = 'it\'s ?' AND id = ?`, Args: []any{1}}).SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, `DELETE FROM t WHERE note = 'it\'s ?' AND id = ?`, sql)
+	assert.Equal(t, []any{1}, args)
+
+	// "?|" and "?&" are JSONB operators only on Postgres
+	sql, args, err = query.Delete("t").Where(query.Condition{Expr: "flags = ?|? AND mask = ?&?", Args: []any{1, 2, 3, 4}}).SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM t WHERE flags = ?|? AND mask = ?&?", sql)
+	assert.Equal(t, []any{1, 2, 3, 4}, args)
+}
+
+func TestPlaceholders_Mismatch(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	bad := query.Condition{Expr: "a = ? AND b = ?", Args: []any{1}}
+
+	_, _, err := query.From("t").Where(bad).SQL()
+	assert.ErrorIs(t, err, query.ErrPlaceholderMismatch)
+
+	sql, args := query.From("t").Where(bad).Build()
+	assert.Equal(t, "", sql)
+	assert.Nil(t, args)
+
+	_, _, err = query.Delete("t").Where(query.Condition{Expr: "a = 1", Args: []any{1}}).SQL()
+	assert.ErrorIs(t, err, query.ErrPlaceholderMismatch)
+}
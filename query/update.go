@@ -0,0 +1,186 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UpdateBuilder builds SQL UPDATE statements with dialect support.
+type UpdateBuilder struct {
+	table     string
+	sets      []assignment
+	where     []Condition
+	returning []string
+	order     string
+	limit     int
+}
+
+// assignment stores a single "col = expr" pair of the SET clause.
+type assignment struct {
+	column string
+	value  Condition
+}
+
+// Update creates a new UpdateBuilder for the given table.
+//
+// Example:
+//
+//	q := query.Update("products").
+//		Set("name", "Pen").
+//		SetExpr("stock", query.Expr("stock + ?", 5)).
+//		Where(query.C("id").Eq(42))
+//
+//	sql, args, err := q.SQL()
+//	 MySQL:    "UPDATE products SET name = ?, stock = stock + ? WHERE id = ?"
+//	 Postgres: "UPDATE products SET name = $1, stock = stock + $2 WHERE id = $3"
+//	 args: ["Pen", 5, 42]
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{table: table, limit: -1}
+}
+
+// Clone returns a deep copy of the builder so variants can be derived
+// without affecting the original.
+func (b *UpdateBuilder) Clone() *UpdateBuilder {
+	c := *b
+	c.sets = cloneSlice(b.sets)
+	c.where = cloneSlice(b.where)
+	c.returning = cloneSlice(b.returning)
+	return &c
+}
+
+// Set assigns a value to a column: "col = ?".
+// RawSQL, Column and Condition values are inlined instead of bound.
+func (b *UpdateBuilder) Set(col string, val any) *UpdateBuilder {
+	expr, args := operand(val)
+	b.sets = append(b.sets, assignment{column: col, value: Condition{Expr: expr, Args: args}})
+	return b
+}
+
+// SetExpr assigns an expression to a column.
+// Example: .SetExpr("counter", query.Expr("counter + ?", 1))
+func (b *UpdateBuilder) SetExpr(col string, expr Condition) *UpdateBuilder {
+	b.sets = append(b.sets, assignment{column: col, value: expr})
+	return b
+}
+
+// SetMap assigns every column in values. Columns are applied in sorted
+// order so the generated SQL is stable.
+func (b *UpdateBuilder) SetMap(values map[string]any) *UpdateBuilder {
+	cols := make([]string, 0, len(values))
+	for col := range values {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+	for _, col := range cols {
+		b.Set(col, values[col])
+	}
+	return b
+}
+
+// Where adds a WHERE clause to the UPDATE query.
+// Multiple calls are joined with AND.
+func (b *UpdateBuilder) Where(conds ...Condition) *UpdateBuilder {
+	b.where = append(b.where, conds...)
+	return b
+}
+
+// Returning specifies columns to return. Only dialects supporting
+// FeatureUpdateReturning (Postgres) accept it.
+func (b *UpdateBuilder) Returning(cols ...string) *UpdateBuilder {
+	b.returning = append(b.returning, cols...)
+	return b
+}
+
+// OrderBy sets the ORDER BY clause. Only dialects supporting
+// FeatureUpdateOrderLimit (MySQL, MariaDB) accept it.
+func (b *UpdateBuilder) OrderBy(order string) *UpdateBuilder {
+	b.order = order
+	return b
+}
+
+// Limit sets the LIMIT clause. Only dialects supporting
+// FeatureUpdateOrderLimit (MySQL, MariaDB) accept it.
+func (b *UpdateBuilder) Limit(limit int) *UpdateBuilder {
+	b.limit = limit
+	return b
+}
+
+// Build assembles the SQL UPDATE query string and returns it with args.
+// It returns an empty string and nil args when the statement is invalid;
+// use SQL to get the reason.
+func (b *UpdateBuilder) Build() (string, []any) {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return "", nil
+	}
+	return sql, args
+}
+
+// SQL assembles the SQL UPDATE query string and returns it with args.
+// Placeholders are numbered across SET and WHERE for the active dialect.
+// It returns an error when the table or assignments are missing, a column is
+// set twice, a clause is not supported by the dialect or a condition's
+// placeholders do not match its arguments.
+func (b *UpdateBuilder) SQL() (string, []any, error) {
+	if b.table == "" {
+		return "", nil, ErrEmptyTable
+	}
+	if len(b.sets) == 0 {
+		return "", nil, ErrNoColumns
+	}
+
+	bd := newBinder()
+	if len(b.returning) > 0 && !Supports(bd.dialect, FeatureUpdateReturning) {
+		return "", nil, unsupported(bd.dialect, "UPDATE ... RETURNING")
+	}
+	if (b.order != "" || b.limit >= 0) && !Supports(bd.dialect, FeatureUpdateOrderLimit) {
+		return "", nil, unsupported(bd.dialect, "UPDATE ... ORDER BY/LIMIT")
+	}
+
+	cols := make([]string, len(b.sets))
+	sets := make([]string, len(b.sets))
+	for i, a := range b.sets {
+		expr, err := bd.bind(a.value)
+		if err != nil {
+			return "", nil, err
+		}
+		cols[i] = a.column
+		sets[i] = fmt.Sprintf("%s = %s", a.column, expr)
+	}
+	if err := checkDuplicateColumns(cols); err != nil {
+		return "", nil, err
+	}
+
+	var sql strings.Builder
+	sql.WriteString("UPDATE ")
+	sql.WriteString(b.table)
+	sql.WriteString(" SET ")
+	sql.WriteString(strings.Join(sets, ", "))
+
+	if len(b.where) > 0 {
+		where, err := bd.list(b.where, " AND ")
+		if err != nil {
+			return "", nil, err
+		}
+		sql.WriteString(" WHERE ")
+		sql.WriteString(where)
+	}
+
+	if b.order != "" {
+		sql.WriteString(" ORDER BY ")
+		sql.WriteString(b.order)
+	}
+
+	if b.limit >= 0 {
+		sql.WriteString(" LIMIT ")
+		sql.WriteString(bd.arg(b.limit))
+	}
+
+	if len(b.returning) > 0 {
+		sql.WriteString(" RETURNING ")
+		sql.WriteString(strings.Join(b.returning, ", "))
+	}
+
+	return sql.String(), bd.args, nil
+}
@@ -0,0 +1,79 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateBuilder_MySQL(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.Update("products").
+		Set("name", "Pen").
+		SetExpr("stock", query.Expr("stock + ?", 5)).
+		Set("updated_at", query.Raw("NOW()")).
+		Where(query.C("id").Eq(42)).
+		SQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE products SET name = ?, stock = stock + ?, updated_at = NOW() WHERE id = ?", sql)
+	assert.Equal(t, []any{"Pen", 5, 42}, args)
+
+	sql, args, err = query.Update("jobs").
+		Set("status", "expired").
+		Where(query.C("status").Eq("pending")).
+		OrderBy("created_at").
+		Limit(100).
+		SQL()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE jobs SET status = ? WHERE status = ? ORDER BY created_at LIMIT ?", sql)
+	assert.Equal(t, []any{"expired", "pending", 100}, args)
+
+	_, _, err = query.Update("jobs").Set("status", "x").Returning("id").SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+}
+
+func TestUpdateBuilder_Postgres(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, args, err := query.Update("accounts").
+		SetMap(map[string]any{"plan": "pro", "active": true}).
+		SetExpr("balance", query.Expr("balance - :amount", query.Named{"amount": 10})).
+		Where(query.C("id").Eq(7), query.Expr("balance >= :amount", query.Named{"amount": 10})).
+		Returning("id", "balance").
+		SQL()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE accounts SET active = $1, plan = $2, balance = balance - $3 "+
+		"WHERE id = $4 AND balance >= $5 RETURNING id, balance", sql)
+	assert.Equal(t, []any{true, "pro", 10, 7, 10}, args)
+
+	_, _, err = query.Update("accounts").Set("plan", "pro").Limit(1).SQL()
+	assert.ErrorIs(t, err, query.ErrUnsupported)
+}
+
+func TestUpdateBuilder_Validation(t *testing.T) {
+	query.SetDialect(query.MySQLDialect{})
+
+	_, _, err := query.Update("").Set("a", 1).SQL()
+	assert.ErrorIs(t, err, query.ErrEmptyTable)
+
+	_, _, err = query.Update("t").Where(query.C("id").Eq(1)).SQL()
+	assert.ErrorIs(t, err, query.ErrNoColumns)
+
+	_, _, err = query.Update("t").Set("a", 1).Set("a", 2).SQL()
+	assert.ErrorIs(t, err, query.ErrDuplicateColumn)
+
+	sql, args := query.Update("t").Set("a", 1).Where(query.Condition{Expr: "b = ?"}).Build()
+	assert.Equal(t, "", sql)
+	assert.Nil(t, args)
+
+	base := query.Update("t").Set("a", 1)
+	sql, _ = base.Clone().Where(query.C("id").Eq(1)).Build()
+	assert.Equal(t, "UPDATE t SET a = ? WHERE id = ?", sql)
+	sql, _ = base.Build()
+	assert.Equal(t, "UPDATE t SET a = ?", sql)
+}
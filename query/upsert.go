@@ -0,0 +1,86 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// conflictClause stores the upsert part of an INSERT.
+type conflictClause struct {
+	target  []string
+	update  []string
+	nothing bool
+}
+
+// OnConflict turns the INSERT into an upsert on the given unique columns.
+// Follow it with DoUpdate or DoNothing.
+//
+// Example:
+//
+//	query.InsertInto("users").
+//		Columns("id", "name").
+//		Values(1, "John").
+//		OnConflict("id").
+//		DoUpdate("name")
+//
+//	 MySQL:    "INSERT INTO users (id, name) VALUES (?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name)"
+//	 Postgres: "INSERT INTO users (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name"
+func (b *InsertBuilder) OnConflict(target ...string) *InsertBuilder {
+	if b.conflict == nil {
+		b.conflict = &conflictClause{}
+	}
+	b.conflict.target = append(b.conflict.target, target...)
+	return b
+}
+
+// DoUpdate overwrites cols with the values proposed for insertion when the
+// row already exists.
+func (b *InsertBuilder) DoUpdate(cols ...string) *InsertBuilder {
+	if b.conflict == nil {
+		b.conflict = &conflictClause{}
+	}
+	b.conflict.update = append(b.conflict.update, cols...)
+	return b
+}
+
+// DoNothing keeps the existing row when it already exists.
+func (b *InsertBuilder) DoNothing() *InsertBuilder {
+	if b.conflict == nil {
+		b.conflict = &conflictClause{}
+	}
+	b.conflict.nothing = true
+	return b
+}
+
+// render returns the upsert clause for dialect d.
+func (c *conflictClause) render(d Dialect) (string, error) {
+	switch {
+	case len(c.target) == 0:
+		return "", fmt.Errorf("%w: DoUpdate or DoNothing requires OnConflict columns", ErrMissingClause)
+	case c.nothing && len(c.update) > 0:
+		return "", fmt.Errorf("%w: DoUpdate with DoNothing", ErrConflictingClause)
+	case !c.nothing && len(c.update) == 0:
+		return "", fmt.Errorf("%w: OnConflict requires DoUpdate or DoNothing", ErrMissingClause)
+	}
+	if err := checkDuplicateColumns(c.update); err != nil {
+		return "", err
+	}
+	ud, ok := d.(UpsertDialect)
+	if !ok {
+		return "", unsupported(d, "ON CONFLICT")
+	}
+	return ud.OnConflict(c.target, c.update), nil
+}
+
+// duplicateKeyUpdate renders the MySQL and MariaDB upsert clause.
+func duplicateKeyUpdate(target, update []string) string {
+	if len(update) == 0 {
+		// a no-op assignment keeps the existing row
+		return fmt.Sprintf("ON DUPLICATE KEY UPDATE %s = %s", target[0], target[0])
+	}
+	sets := make([]string, len(update))
+	for i, col := range update {
+		sets[i] = fmt.Sprintf("%s = VALUES(%s)", col, col)
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
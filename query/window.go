@@ -0,0 +1,147 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WindowBuilder builds a window specification used by OVER (...) and
+// the WINDOW clause.
+//
+// Example:
+//
+//	query.RowNumber().
+//		Over(query.Window().PartitionBy("customer_id").OrderBy("created_at DESC")).
+//		As("rn")
+//
+//	 "ROW_NUMBER() OVER (PARTITION BY customer_id ORDER BY created_at DESC) AS rn"
+type WindowBuilder struct {
+	base      string
+	partition []string
+	order     []string
+	frame     string
+}
+
+// namedWindow stores a single "WINDOW name AS (...)" definition.
+type namedWindow struct {
+	name string
+	spec *WindowBuilder
+}
+
+// Window starts a new window specification.
+func Window() *WindowBuilder {
+	return &WindowBuilder{}
+}
+
+// From bases the specification on a named window: "(w ORDER BY ...)".
+func (w *WindowBuilder) From(name string) *WindowBuilder {
+	w.base = name
+	return w
+}
+
+// PartitionBy sets the PARTITION BY columns.
+func (w *WindowBuilder) PartitionBy(cols ...string) *WindowBuilder {
+	w.partition = append(w.partition, cols...)
+	return w
+}
+
+// OrderBy sets the ORDER BY clause of the window.
+// Example: .OrderBy("created_at DESC", "id")
+func (w *WindowBuilder) OrderBy(order ...string) *WindowBuilder {
+	w.order = append(w.order, order...)
+	return w
+}
+
+// Frame sets the frame clause.
+// Example: .Frame("ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW")
+func (w *WindowBuilder) Frame(frame string) *WindowBuilder {
+	w.frame = frame
+	return w
+}
+
+// String renders the specification without the surrounding parentheses.
+func (w *WindowBuilder) String() string {
+	var parts []string
+	if w.base != "" {
+		parts = append(parts, w.base)
+	}
+	if len(w.partition) > 0 {
+		parts = append(parts, "PARTITION BY "+strings.Join(w.partition, ", "))
+	}
+	if len(w.order) > 0 {
+		parts = append(parts, "ORDER BY "+strings.Join(w.order, ", "))
+	}
+	if w.frame != "" {
+		parts = append(parts, w.frame)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Over applies a window to an expression: "expr OVER (spec)".
+// Example: query.Sum("amount").Over(query.Window().PartitionBy("customer_id"))
+func (c Condition) Over(w *WindowBuilder) Condition {
+	return Condition{Expr: fmt.Sprintf("%s OVER (%s)", c.Expr, w), Args: c.Args}
+}
+
+// OverWindow applies a named window declared with Builder.Window: "expr OVER name".
+func (c Condition) OverWindow(name string) Condition {
+	return Condition{Expr: fmt.Sprintf("%s OVER %s", c.Expr, name), Args: c.Args}
+}
+
+// Window declares a named window: "WINDOW name AS (spec)".
+// Example: .Window("w", query.Window().PartitionBy("customer_id"))
+func (b *Builder) Window(name string, spec *WindowBuilder) *Builder {
+	b.windows = append(b.windows, namedWindow{name: name, spec: spec})
+	return b
+}
+
+//
+// --- Window functions ---
+//
+
+// RowNumber creates "ROW_NUMBER()".
+func RowNumber() Condition {
+	return Condition{Expr: "ROW_NUMBER()"}
+}
+
+// Rank creates "RANK()".
+func Rank() Condition {
+	return Condition{Expr: "RANK()"}
+}
+
+// DenseRank creates "DENSE_RANK()".
+func DenseRank() Condition {
+	return Condition{Expr: "DENSE_RANK()"}
+}
+
+// NTile creates "NTILE(n)".
+func NTile(n int) Condition {
+	return Condition{Expr: fmt.Sprintf("NTILE(%d)", n)}
+}
+
+// Lag creates "LAG(col)" or "LAG(col, offset)".
+func Lag(col string, offset ...int) Condition {
+	return offsetFunc("LAG", col, offset)
+}
+
+// Lead creates "LEAD(col)" or "LEAD(col, offset)".
+func Lead(col string, offset ...int) Condition {
+	return offsetFunc("LEAD", col, offset)
+}
+
+// FirstValue creates "FIRST_VALUE(col)".
+func FirstValue(col string) Condition {
+	return Condition{Expr: fmt.Sprintf("FIRST_VALUE(%s)", col)}
+}
+
+// LastValue creates "LAST_VALUE(col)".
+func LastValue(col string) Condition {
+	return Condition{Expr: fmt.Sprintf("LAST_VALUE(%s)", col)}
+}
+
+func offsetFunc(fn, col string, offset []int) Condition {
+	if len(offset) > 0 {
+		return Condition{Expr: fmt.Sprintf("%s(%s, %d)", fn, col, offset[0])}
+	}
+	return Condition{Expr: fmt.Sprintf("%s(%s)", fn, col)}
+}
@@ -0,0 +1,48 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowFunctions(t *testing.T) {
+	w := query.Window().PartitionBy("customer_id").OrderBy("created_at DESC", "id")
+
+	assert.Equal(t, "ROW_NUMBER() OVER (PARTITION BY customer_id ORDER BY created_at DESC, id) AS rn",
+		query.RowNumber().Over(w).As("rn").Expr)
+	assert.Equal(t, "RANK() OVER (ORDER BY score DESC)",
+		query.Rank().Over(query.Window().OrderBy("score DESC")).Expr)
+	assert.Equal(t, "DENSE_RANK() OVER w", query.DenseRank().OverWindow("w").Expr)
+	assert.Equal(t, "NTILE(4) OVER w", query.NTile(4).OverWindow("w").Expr)
+	assert.Equal(t, "LAG(amount) OVER w", query.Lag("amount").OverWindow("w").Expr)
+	assert.Equal(t, "LEAD(amount, 2) OVER w", query.Lead("amount", 2).OverWindow("w").Expr)
+	assert.Equal(t, "FIRST_VALUE(amount) OVER w", query.FirstValue("amount").OverWindow("w").Expr)
+	assert.Equal(t, "LAST_VALUE(amount) OVER w", query.LastValue("amount").OverWindow("w").Expr)
+	assert.Equal(t, "SUM(amount) OVER (w ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS running_total",
+		query.Sum("amount").
+			Over(query.Window().From("w").Frame("ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW")).
+			As("running_total").Expr)
+}
+
+func TestBuilder_NamedWindow(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	sql, args := query.From("orders").
+		Select("id").
+		SelectExpr(
+			query.RowNumber().OverWindow("w").As("rn"),
+			query.Sum(query.Case().When(query.C("status").Eq("paid"), query.C("amount")).Else(0)).OverWindow("w").As("paid"),
+		).
+		Where(query.C("tenant_id").Eq(3)).
+		Window("w", query.Window().PartitionBy("customer_id").OrderBy("created_at")).
+		OrderBy("id").
+		Build()
+
+	assert.Equal(t, "SELECT id, ROW_NUMBER() OVER w AS rn, "+
+		"SUM(CASE WHEN status = $1 THEN amount ELSE $2 END) OVER w AS paid FROM orders "+
+		"WHERE tenant_id = $3 WINDOW w AS (PARTITION BY customer_id ORDER BY created_at) ORDER BY id", sql)
+	assert.Equal(t, []any{"paid", 0, 3}, args)
+}
@@ -0,0 +1,313 @@
+// Package queue implements a database-backed job queue on top of oca.
+//
+// Jobs live in a single table that combines the payload columns of T with a
+// fixed set of bookkeeping columns:
+//
+//	CREATE TABLE email_jobs (
+//	    id           BIGSERIAL PRIMARY KEY,
+//	    status       TEXT        NOT NULL DEFAULT 'pending',
+//	    attempts     INT         NOT NULL DEFAULT 0,
+//	    run_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+//	    locked_until TIMESTAMPTZ,
+//	    last_error   TEXT,
+//	    -- payload columns, mapped by the `db` tags of T
+//	    recipient    TEXT NOT NULL,
+//	    subject      TEXT NOT NULL
+//	);
+//
+// Consumers claim jobs with SELECT ... FOR UPDATE SKIP LOCKED, so several
+// workers can poll the same table without handing out a job twice. A claimed
+// job that is neither acked nor nacked before its visibility timeout expires
+// becomes claimable again. Ack and Nack only apply while the caller still
+// holds its claim, and every timestamp is taken from the database clock, the
+// same one the run_at default uses.
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mhdiiilham/oca"
+	"github.com/mhdiiilham/oca/query"
+)
+
+// Job statuses stored in the status column.
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDead    = "dead"
+)
+
+// ErrJobLost is returned by Ack and Nack when the job is no longer claimed by
+// the caller: it was removed, or its visibility timeout expired and another
+// consumer claimed it again.
+var ErrJobLost = errors.New("queue: job is no longer claimed")
+
+// Job is a claimed job: its ID, the number of attempts including the current
+// one, and the payload mapped from the job row. The attempt count also
+// identifies the claim, so Ack and Nack of a job claimed again elsewhere fail.
+type Job[T any] struct {
+	ID       int64
+	Attempts int
+	Payload  T
+}
+
+// Queue is a job queue stored in a database table.
+type Queue[T any] struct {
+	db      *sql.DB
+	table   string
+	repo    oca.GenericStore[T]
+	options options
+}
+
+// Option configures a Queue.
+type Option func(*options)
+
+type options struct {
+	maxAttempts int
+	backoff     func(attempt int) time.Duration
+	visibility  time.Duration
+}
+
+// WithMaxAttempts sets how many times a job is tried before it is moved to
+// the dead status. The default is 5.
+func WithMaxAttempts(n int) Option {
+	return func(o *options) { o.maxAttempts = n }
+}
+
+// WithBackoff sets the delay before a nacked job is retried, given the number
+// of attempts made so far. The default doubles from one second, up to an hour.
+func WithBackoff(backoff func(attempt int) time.Duration) Option {
+	return func(o *options) { o.backoff = backoff }
+}
+
+// WithVisibilityTimeout sets how long a claimed job stays invisible to other
+// consumers. The default is five minutes.
+func WithVisibilityTimeout(d time.Duration) Option {
+	return func(o *options) { o.visibility = d }
+}
+
+// ExponentialBackoff returns base * 2^(attempt-1), capped at max.
+func ExponentialBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			return max
+		}
+		return d
+	}
+}
+
+// New returns a queue whose jobs are stored in table. The payload columns are
+// mapped with the `db` tags of T, as with oca.Repository.
+//
+// Example:
+//
+//	type Email struct {
+//	    Recipient string `db:"recipient"`
+//	    Subject   string `db:"subject"`
+//	}
+//
+//	q := queue.New[Email](db, "email_jobs", queue.WithMaxAttempts(3))
+//	err := q.Enqueue(ctx, &Email{Recipient: "a@example.com", Subject: "Hi"})
+func New[T any](db *sql.DB, table string, opts ...Option) *Queue[T] {
+	o := options{
+		maxAttempts: 5,
+		backoff:     ExponentialBackoff(time.Second, time.Hour),
+		visibility:  5 * time.Minute,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return &Queue[T]{
+		db:      db,
+		table:   table,
+		repo:    oca.NewRepository[T](db, oca.WithTableName(table)),
+		options: o,
+	}
+}
+
+// Enqueue inserts a job with the given payload. The bookkeeping columns take
+// their table defaults, so the job is pending and runnable immediately.
+func (q *Queue[T]) Enqueue(ctx context.Context, payload *T) error {
+	return q.repo.Insert(ctx, payload)
+}
+
+// claimRow is the bookkeeping part of a job row read while claiming.
+type claimRow struct {
+	ID       int64  `db:"id"`
+	Status   string `db:"status"`
+	Attempts int    `db:"attempts"`
+}
+
+// Claim locks up to n runnable jobs, marks them running for the visibility
+// timeout and returns them in ID order. Jobs locked by other consumers are
+// skipped. A job whose visibility timeout expired after its last allowed
+// attempt is moved to the dead status instead of being returned.
+func (q *Queue[T]) Claim(ctx context.Context, n int) (jobs []Job[T], err error) {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	now, err := dbNow(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	sel := query.From(q.table).
+		Select("id", "status", "attempts").
+		Where(query.Or(
+			query.And(query.C("status").Eq(StatusPending), query.C("run_at").Lte(now)),
+			query.And(query.C("status").Eq(StatusRunning), query.C("locked_until").Lte(now)),
+		)).
+		OrderBy("id").
+		Limit(n).
+		ForUpdate().
+		SkipLocked()
+	rows, err := oca.Select[claimRow](ctx, tx, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimed, expired []any
+	attempts := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		if row.Status == StatusRunning && row.Attempts >= q.options.maxAttempts {
+			expired = append(expired, row.ID)
+			continue
+		}
+		claimed = append(claimed, row.ID)
+		attempts[row.ID] = row.Attempts + 1
+	}
+
+	if len(expired) > 0 {
+		dead := query.Update(q.table).
+			Set("status", StatusDead).
+			Set("locked_until", nil).
+			Set("last_error", "visibility timeout expired").
+			Where(query.C("id").In(expired...))
+		if err = q.exec(ctx, tx, dead); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(claimed) > 0 {
+		run := query.Update(q.table).
+			Set("status", StatusRunning).
+			Set("attempts", query.Expr("attempts + 1")).
+			Set("locked_until", now.Add(q.options.visibility)).
+			Where(query.C("id").In(claimed...))
+		if err = q.exec(ctx, tx, run); err != nil {
+			return nil, err
+		}
+
+		var payloads []T
+		repo := oca.NewTxRepository[T](tx, oca.WithTableName(q.table))
+		payloads, err = repo.Finds(ctx, oca.Where(query.C("id").In(claimed...)), oca.OrderBy("id"))
+		if err != nil {
+			return nil, err
+		}
+		if len(payloads) != len(claimed) {
+			err = fmt.Errorf("queue: claimed %d jobs but loaded %d payloads", len(claimed), len(payloads))
+			return nil, err
+		}
+		for i, id := range claimed {
+			id := id.(int64)
+			jobs = append(jobs, Job[T]{ID: id, Attempts: attempts[id], Payload: payloads[i]})
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Ack marks a job as done by removing it from the table. It returns
+// ErrJobLost when the caller no longer holds the claim.
+func (q *Queue[T]) Ack(ctx context.Context, job Job[T]) error {
+	return q.exec(ctx, q.db, query.Delete(q.table).Where(claimedBy(job.ID, job.Attempts)))
+}
+
+// Nack records a failed attempt. The job is scheduled again after the backoff
+// delay, or moved to the dead status once it has used all its attempts. It
+// returns ErrJobLost when the caller no longer holds the claim.
+func (q *Queue[T]) Nack(ctx context.Context, job Job[T], cause error) error {
+	lastError := "unknown error"
+	if cause != nil {
+		lastError = cause.Error()
+	}
+
+	b := query.Update(q.table).
+		Set("locked_until", nil).
+		Set("last_error", lastError).
+		Where(claimedBy(job.ID, job.Attempts))
+	if job.Attempts >= q.options.maxAttempts {
+		b.Set("status", StatusDead)
+	} else {
+		now, err := dbNow(ctx, q.db)
+		if err != nil {
+			return err
+		}
+		b.Set("status", StatusPending).
+			Set("run_at", now.Add(q.options.backoff(job.Attempts)))
+	}
+	return q.exec(ctx, q.db, b)
+}
+
+// claimedBy matches the job id while it is running under the claim that
+// brought it to attempts.
+func claimedBy(id int64, attempts int) query.Condition {
+	return query.And(
+		query.C("id").Eq(id),
+		query.C("status").Eq(StatusRunning),
+		query.C("attempts").Eq(attempts),
+	)
+}
+
+// dbNow returns the current time of the database.
+func dbNow(ctx context.Context, db oca.DBTX) (time.Time, error) {
+	var now time.Time
+	if err := db.QueryRowContext(ctx, "SELECT CURRENT_TIMESTAMP").Scan(&now); err != nil {
+		return time.Time{}, err
+	}
+	return now, nil
+}
+
+// statement is implemented by the query builders used by the queue.
+type statement interface {
+	SQL() (string, []any, error)
+}
+
+// exec runs a statement that must affect at least one row.
+func (q *Queue[T]) exec(ctx context.Context, db oca.DBTX, b statement) error {
+	sqlStr, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+	res, err := db.ExecContext(ctx, sqlStr, args...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrJobLost
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+type email struct {
+	Recipient string `db:"recipient"`
+	Subject   string `db:"subject"`
+}
+
+func TestQueue_Enqueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO email_jobs \(recipient, subject\) VALUES \(\?, \?\)`).
+		WithArgs("a@example.com", "Hi").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	q := queue.New[email](db, "email_jobs")
+	assert.NoError(t, q.Enqueue(context.Background(), &email{Recipient: "a@example.com", Subject: "Hi"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueue_Claim(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	dbTime := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT CURRENT_TIMESTAMP`).
+		WillReturnRows(sqlmock.NewRows([]string{"now"}).AddRow(dbTime))
+	mock.ExpectQuery(`SELECT id, status, attempts FROM email_jobs WHERE \(\(status = \? AND run_at <= \?\) OR \(status = \? AND locked_until <= \?\)\) ORDER BY id LIMIT \? FOR UPDATE SKIP LOCKED`).
+		WithArgs("pending", dbTime, "running", dbTime, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "attempts"}).
+			AddRow(int64(1), "pending", 0).
+			AddRow(int64(2), "running", 3).
+			AddRow(int64(3), "running", 1))
+	mock.ExpectExec(`UPDATE email_jobs SET status = \?, locked_until = \?, last_error = \? WHERE id IN \(\?\)`).
+		WithArgs("dead", nil, "visibility timeout expired", int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE email_jobs SET status = \?, attempts = attempts \+ 1, locked_until = \? WHERE id IN \(\?,\?\)`).
+		WithArgs("running", dbTime.Add(5*time.Minute), int64(1), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`SELECT recipient, subject FROM email_jobs WHERE id IN \(\?,\?\) ORDER BY id`).
+		WithArgs(int64(1), int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"recipient", "subject"}).
+			AddRow("a@example.com", "Hi").
+			AddRow("b@example.com", "Hello"))
+	mock.ExpectCommit()
+
+	q := queue.New[email](db, "email_jobs", queue.WithMaxAttempts(3))
+	jobs, err := q.Claim(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []queue.Job[email]{
+		{ID: 1, Attempts: 1, Payload: email{Recipient: "a@example.com", Subject: "Hi"}},
+		{ID: 3, Attempts: 2, Payload: email{Recipient: "b@example.com", Subject: "Hello"}},
+	}, jobs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueue_ClaimRollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT CURRENT_TIMESTAMP`).
+		WillReturnRows(sqlmock.NewRows([]string{"now"}).AddRow(time.Now()))
+	mock.ExpectQuery(`SELECT id, status, attempts FROM email_jobs`).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	_, err = queue.New[email](db, "email_jobs").Claim(context.Background(), 1)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueue_AckAndNack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	q := queue.New[email](db, "email_jobs",
+		queue.WithMaxAttempts(2),
+		queue.WithBackoff(func(int) time.Duration { return time.Minute }),
+	)
+	ctx := context.Background()
+
+	dbTime := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	claim := `\(id = \? AND status = \? AND attempts = \?\)`
+
+	mock.ExpectExec(`DELETE FROM email_jobs WHERE `+claim).
+		WithArgs(int64(1), "running", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, q.Ack(ctx, queue.Job[email]{ID: 1, Attempts: 1}))
+
+	mock.ExpectQuery(`SELECT CURRENT_TIMESTAMP`).
+		WillReturnRows(sqlmock.NewRows([]string{"now"}).AddRow(dbTime))
+	mock.ExpectExec(`UPDATE email_jobs SET locked_until = \?, last_error = \?, status = \?, run_at = \? WHERE `+claim).
+		WithArgs(nil, "smtp down", "pending", dbTime.Add(time.Minute), int64(2), "running", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, q.Nack(ctx, queue.Job[email]{ID: 2, Attempts: 1}, errors.New("smtp down")))
+
+	mock.ExpectExec(`UPDATE email_jobs SET locked_until = \?, last_error = \?, status = \? WHERE `+claim).
+		WithArgs(nil, "smtp down", "dead", int64(2), "running", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, q.Nack(ctx, queue.Job[email]{ID: 2, Attempts: 2}, errors.New("smtp down")))
+
+	// the job was claimed again by another consumer after its timeout expired
+	mock.ExpectExec(`DELETE FROM email_jobs WHERE `+claim).
+		WithArgs(int64(9), "running", 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, q.Ack(ctx, queue.Job[email]{ID: 9, Attempts: 1}), queue.ErrJobLost)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := queue.ExponentialBackoff(time.Second, 10*time.Second)
+	assert.Equal(t, time.Second, backoff(1))
+	assert.Equal(t, 4*time.Second, backoff(3))
+	assert.Equal(t, 10*time.Second, backoff(10))
+}
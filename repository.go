@@ -1,17 +1,79 @@
 package oca
 
 import (
+	"context"
 	"database/sql"
 )
 
+// DBTX is the subset of *sql.DB and *sql.Tx used by the repository, so the
+// same code can run inside or outside a transaction.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 // Repository provides basic CRUD operations for any model T.
 // If T implements Tabler, its TableName() will be used.
 // Otherwise, the struct name is used as the table name (lowercased + "s").
 type Repository[T any] struct {
-	db *sql.DB
+	db    DBTX
+	inTx  bool
+	table string
+	clock Clock
+}
+
+// RepositoryOption configures a Repository.
+type RepositoryOption func(*repositoryConfig)
+
+type repositoryConfig struct {
+	table string
+	clock Clock
+}
+
+// WithTableName overrides the table name resolved from T, so the same
+// struct can be mapped to several tables.
+//
+// Example:
+//
+//	repo := oca.NewRepository[Event](db, oca.WithTableName("events_2025"))
+func WithTableName(name string) RepositoryOption {
+	return func(c *repositoryConfig) { c.table = name }
 }
 
 // NewRepository returns a new generic repository.
-func NewRepository[T any](db *sql.DB) GenericStore[T] {
-	return &Repository[T]{db: db}
+func NewRepository[T any](db *sql.DB, opts ...RepositoryOption) Store[T] {
+	return newRepository[T](db, false, opts)
+}
+
+// NewTxRepository returns a repository whose statements run inside tx.
+// Row locks requested with ForUpdate or ForShare are only allowed on such a
+// repository, and they are held until tx commits or rolls back.
+//
+// Example:
+//
+//	tx, err := db.BeginTx(ctx, nil)
+//	repo := oca.NewTxRepository[Job](tx)
+//	jobs, err := repo.Finds(ctx, oca.Where(query.C("status").Eq("pending")), oca.Limit(10), oca.ForUpdate(), oca.SkipLocked())
+func NewTxRepository[T any](tx *sql.Tx, opts ...RepositoryOption) Store[T] {
+	return newRepository[T](tx, true, opts)
+}
+
+func newRepository[T any](db DBTX, inTx bool, opts []RepositoryOption) *Repository[T] {
+	var cfg repositoryConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return &Repository[T]{db: db, inTx: inTx, table: cfg.table, clock: cfg.clock}
+}
+
+// tableName returns the table set with WithTableName, or the one resolved from T.
+func (r *Repository[T]) tableName() string {
+	if r.table != "" {
+		return r.table
+	}
+	var entity T
+	return resolveTableName(entity)
 }
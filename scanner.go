@@ -26,8 +26,7 @@ func scanRows[T any](rows *sql.Rows) ([]T, error) {
 			if !ok {
 				return nil, fmt.Errorf("scanRows: cannot map column %s", col)
 			}
-			// Use slice of int for FieldByIndex
-			scanTargets[i] = val.FieldByIndex([]int{index}).Addr().Interface()
+			scanTargets[i] = val.FieldByIndex(index).Addr().Interface()
 		}
 
 		if err := rows.Scan(scanTargets...); err != nil {
@@ -46,7 +45,7 @@ func scanRows[T any](rows *sql.Rows) ([]T, error) {
 
 // scanRowSingle maps sql.Row (single row) into a struct of type T.
 // Useful for FindOne queries or returning auto-generated IDs.
-func scanRowSingle[T any](row *sql.Row, columns []string, colMap map[string]int) (*T, error) {
+func scanRowSingle[T any](row *sql.Row, columns []string, colMap map[string][]int) (*T, error) {
 	var entity T
 	val := reflect.New(reflect.TypeOf(entity)).Elem()
 	scanTargets := make([]interface{}, len(columns))
@@ -56,7 +55,7 @@ func scanRowSingle[T any](row *sql.Row, columns []string, colMap map[string]int)
 		if !ok {
 			return nil, fmt.Errorf("scanRowSingle: cannot map column %s", col)
 		}
-		scanTargets[i] = val.FieldByIndex([]int{index}).Addr().Interface()
+		scanTargets[i] = val.FieldByIndex(index).Addr().Interface()
 	}
 
 	if err := row.Scan(scanTargets...); err != nil {
@@ -75,7 +74,7 @@ func scanAutoFields[T any](ctx context.Context, db *sql.DB, sqlStr string, sqlAr
 	scanTargets := make([]interface{}, len(autoFields))
 
 	for i, f := range autoFields {
-		field := val.Field(f.Index)
+		field := val.FieldByIndex(f.index())
 		if !field.CanAddr() {
 			return &reflect.ValueError{Method: "scanAutoFields", Kind: field.Kind()}
 		}
@@ -85,12 +84,52 @@ func scanAutoFields[T any](ctx context.Context, db *sql.DB, sqlStr string, sqlAr
 	return row.Scan(scanTargets...)
 }
 
-// buildColumnMap maps struct columns to their field indices.
-func buildColumnMap(entity any) map[string]int {
-	colMap := make(map[string]int)
+// buildColumnMap maps struct columns to their field index paths.
+func buildColumnMap(entity any) map[string][]int {
+	colMap := make(map[string][]int)
 	metas := getStructMeta(reflect.TypeOf(entity))
 	for _, m := range metas {
-		colMap[m.Column] = m.Index
+		colMap[m.Column] = m.index()
 	}
 	return colMap
 }
+
+// scanRowsByName maps sql.Rows into a slice of T using the column names
+// reported by the result set, so arbitrary projections (aliases, aggregates,
+// window functions) can be scanned into DTO structs.
+func scanRowsByName[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	var entity T
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	colMap := buildColumnMap(entity)
+
+	var result []T
+	for rows.Next() {
+		val := reflect.New(reflect.TypeOf(entity)).Elem()
+		scanTargets := make([]interface{}, len(columns))
+
+		for i, col := range columns {
+			index, ok := colMap[col]
+			if !ok {
+				return nil, fmt.Errorf("scanRowsByName: cannot map column %s", col)
+			}
+			scanTargets[i] = val.FieldByIndex(index).Addr().Interface()
+		}
+
+		if err := rows.Scan(scanTargets...); err != nil {
+			return nil, err
+		}
+
+		result = append(result, val.Interface().(T))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -50,7 +50,7 @@ func Test_scanRowSingle(t *testing.T) {
 	row := db.QueryRow("SELECT id, name, created_at FROM users WHERE id = ?", 1)
 
 	columns := []string{"id", "name", "created_at"}
-	colMap := map[string]int{"id": 0, "name": 1, "created_at": 2}
+	colMap := map[string][]int{"id": {0}, "name": {1}, "created_at": {2}}
 
 	result, err := scanRowSingle[userTest](row, columns, colMap)
 	assert.NoError(t, err)
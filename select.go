@@ -0,0 +1,71 @@
+package oca
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/mhdiiilham/oca/query"
+)
+
+// Select runs a query built with the query package and scans the result into
+// a slice of T. Result columns are matched to fields by their `db` tag, so T
+// can be a DTO describing a projection (aliases, aggregates, window functions)
+// rather than a table model.
+//
+// Example:
+//
+//	type RankedOrder struct {
+//	    ID   int64 `db:"id"`
+//	    Rank int   `db:"rn"`
+//	}
+//
+//	b := query.From("orders").
+//	    Select("id").
+//	    SelectExpr(query.RowNumber().Over(query.Window().PartitionBy("customer_id").OrderBy("amount DESC")).As("rn"))
+//	rows, err := oca.Select[RankedOrder](ctx, db, b)
+//
+// db may be a *sql.DB, a *sql.Tx, a *sql.Conn or a wrapper implementing
+// Transactional. On a *sql.DB, Select runs in the ambient transaction of ctx
+// when there is one (see InTx). A *sql.Conn is not known to be in a
+// transaction; wrap it in a Transactional to lock rows through it. A builder with a row lock (ForUpdate,
+// ForShare) must run in a transaction; otherwise Select returns ErrLockOutsideTx.
+func Select[T any](ctx context.Context, db DBTX, b *query.Builder) ([]T, error) {
+	db, inTx := selectConn(ctx, db)
+	if b.Locking() && !inTx {
+		return nil, ErrLockOutsideTx
+	}
+	sqlStr, args, err := b.SQL()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, sqlStr, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRowsByName[T](rows)
+}
+
+// Transactional is implemented by DBTX wrappers, such as an instrumented
+// *sql.Tx, to report whether their statements run inside a transaction.
+type Transactional interface {
+	InTransaction() bool
+}
+
+// selectConn returns the handle Select runs on and whether it is
+// transactional.
+func selectConn(ctx context.Context, db DBTX) (DBTX, bool) {
+	switch v := db.(type) {
+	case *sql.Tx:
+		return db, true
+	case Transactional:
+		return db, v.InTransaction()
+	case *sql.DB:
+		if tx, ok := TxFromContext(ctx); ok {
+			return tx, true
+		}
+	}
+	return db, false
+}
@@ -0,0 +1,55 @@
+package oca_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+type rankedOrder struct {
+	ID         int64   `db:"id"`
+	Rank       int     `db:"rn"`
+	RunningSum float64 `db:"running_total"`
+}
+
+func TestSelect_WindowFunctionsIntoDTO(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	w := query.Window().PartitionBy("customer_id").OrderBy("created_at")
+	b := query.From("orders").
+		Select("id").
+		SelectExpr(
+			query.RowNumber().Over(w).As("rn"),
+			query.Sum("amount").Over(w).As("running_total"),
+		).
+		Where(query.C("customer_id").Eq(5))
+
+	mock.ExpectQuery(`SELECT id, ROW_NUMBER\(\) OVER \(PARTITION BY customer_id ORDER BY created_at\) AS rn, SUM\(amount\) OVER \(PARTITION BY customer_id ORDER BY created_at\) AS running_total FROM orders WHERE customer_id = \?`).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "rn", "running_total"}).
+			AddRow(10, 1, 20.5).
+			AddRow(11, 2, 30.0))
+
+	rows, err := oca.Select[rankedOrder](context.Background(), db, b)
+	assert.NoError(t, err)
+	assert.Equal(t, []rankedOrder{{ID: 10, Rank: 1, RunningSum: 20.5}, {ID: 11, Rank: 2, RunningSum: 30}}, rows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSelect_UnknownColumn(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id, total FROM orders`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "total"}).AddRow(1, 2))
+
+	_, err = oca.Select[rankedOrder](context.Background(), db, query.From("orders").Select("id", "total"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package oca
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/mhdiiilham/oca/query"
+)
+
+// Soft delete
+//
+// A model with a field tagged `schema:"soft_delete"` is soft-deleted: Delete
+// sets the field to the current time instead of removing the row, and
+// Finds, FindOne and Count only see rows where it is NULL.
+//
+//	type Post struct {
+//	    ID        int64        `db:"id,pk,auto"`
+//	    Title     string       `db:"title"`
+//	    DeletedAt sql.NullTime `db:"deleted_at" schema:"soft_delete"`
+//	}
+//
+// The field must be a *time.Time or sql.NullTime so that it can hold NULL;
+// Delete and Restore reject a plain time.Time. Insert and Upsert never write
+// it, so new records start out live.
+
+// WithTrashed includes soft-deleted records in the results.
+func WithTrashed() FilterOptions {
+	return func(ff *FindFilter) { ff.WithTrashed = true }
+}
+
+// OnlyTrashed returns only soft-deleted records.
+func OnlyTrashed() FilterOptions {
+	return func(ff *FindFilter) { ff.OnlyTrashed = true }
+}
+
+// softDeleteField returns the field tagged `schema:"soft_delete"`, if any.
+func softDeleteField(t reflect.Type) (FieldMeta, bool) {
+	for _, m := range getStructMeta(t) {
+		if m.hasSchema("soft_delete") {
+			return m, true
+		}
+	}
+	return FieldMeta{}, false
+}
+
+// checkSoftDeleteField reports whether a soft delete field can hold NULL.
+func checkSoftDeleteField(m FieldMeta, typ reflect.Type) error {
+	switch typ {
+	case reflect.TypeOf(&time.Time{}), reflect.TypeOf(sql.NullTime{}):
+		return nil
+	default:
+		return fmt.Errorf("oca: soft delete field %s must be *time.Time or sql.NullTime, got %s", m.Name, typ)
+	}
+}
+
+// softDeleteScope returns the condition hiding or selecting soft-deleted rows
+// for filter f, or false when T is not soft-deleted or f includes every row.
+func softDeleteScope[T any](f FindFilter) (query.Condition, bool) {
+	var entity T
+	sd, ok := softDeleteField(reflect.TypeOf(entity))
+	switch {
+	case !ok || f.WithTrashed:
+		return query.Condition{}, false
+	case f.OnlyTrashed:
+		return query.C(sd.Column).IsNotNull(), true
+	default:
+		return query.C(sd.Column).IsNull(), true
+	}
+}
@@ -0,0 +1,137 @@
+package oca_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+type Post struct {
+	ID        int64        `db:"id,pk,auto"`
+	Title     string       `db:"title"`
+	DeletedAt sql.NullTime `db:"deleted_at" schema:"soft_delete"`
+}
+
+func TestRepository_SoftDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Post](db)
+	post := &Post{ID: 7, Title: "Hello"}
+
+	mock.ExpectExec(`UPDATE posts SET deleted_at = \? WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(sqlmock.AnyArg(), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Delete(context.Background(), post))
+	assert.True(t, post.DeletedAt.Valid)
+
+	mock.ExpectExec(`UPDATE posts SET deleted_at = \? WHERE id = \?`).
+		WithArgs(nil, int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Restore(context.Background(), post))
+	assert.False(t, post.DeletedAt.Valid)
+
+	mock.ExpectExec(`DELETE FROM posts WHERE id = \?`).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.ForceDelete(context.Background(), post))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_SoftDeleteScopes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Post](db)
+	ctx := context.Background()
+	cols := []string{"id", "title", "deleted_at"}
+
+	mock.ExpectQuery(`SELECT id, title, deleted_at FROM posts WHERE title = \? AND deleted_at IS NULL`).
+		WithArgs("Hello").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(1, "Hello", nil))
+	posts, err := repo.Finds(ctx, oca.Where(query.C("title").Eq("Hello")))
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+
+	mock.ExpectQuery(`SELECT id, title, deleted_at FROM posts$`).
+		WillReturnRows(sqlmock.NewRows(cols))
+	_, err = repo.Finds(ctx, oca.WithTrashed())
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT id, title, deleted_at FROM posts WHERE deleted_at IS NOT NULL LIMIT \?`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(cols))
+	_, err = repo.FindOne(ctx, oca.OnlyTrashed())
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts WHERE deleted_at IS NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	n, err := repo.Count(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_DeleteWithoutSoftDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`DELETE FROM autousers WHERE id = \?`).
+		WithArgs(int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := oca.NewRepository[AutoUser](db)
+	assert.NoError(t, repo.Delete(context.Background(), &AutoUser{ID: 3}))
+	assert.ErrorIs(t, repo.Restore(context.Background(), &AutoUser{ID: 3}), oca.ErrNoSoftDelete)
+	assert.ErrorIs(t, oca.NewRepository[SimpleUser](db).Delete(context.Background(), &SimpleUser{}), oca.ErrNoPrimaryKey)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_SoftDeleteNotWritten(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Post](db)
+	ctx := context.Background()
+	post := &Post{ID: 7, Title: "Hello", DeletedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+
+	mock.ExpectQuery(`INSERT INTO posts \(title\) VALUES \(\?\) RETURNING id`).
+		WithArgs("Hello").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	assert.NoError(t, repo.Insert(ctx, post))
+
+	mock.ExpectExec(`INSERT INTO posts \(id, title\) VALUES \(\?, \?\) ON DUPLICATE KEY UPDATE title = VALUES\(title\)`).
+		WithArgs(int64(8), "Hello").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Upsert(ctx, post))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_SoftDeleteRequiresNullable(t *testing.T) {
+	type Note struct {
+		ID        int64     `db:"id,pk"`
+		DeletedAt time.Time `db:"deleted_at" schema:"soft_delete"`
+	}
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Note](db, oca.WithTableName("notes"))
+	assert.ErrorContains(t, repo.Delete(context.Background(), &Note{ID: 1}), "must be *time.Time or sql.NullTime")
+	assert.ErrorContains(t, repo.Restore(context.Background(), &Note{ID: 1}), "must be *time.Time or sql.NullTime")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,100 @@
+package oca
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Clock supplies the current time for autoCreateTime, autoUpdateTime and
+// soft delete fields. Timestamps are computed client-side, so every write
+// of a single call uses the same instant.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to Clock.
+//
+// Example:
+//
+//	frozen := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+//	repo := oca.NewRepository[User](db, oca.WithClock(oca.ClockFunc(func() time.Time { return frozen })))
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// UTCClock is the default clock: the system time in UTC.
+var UTCClock Clock = ClockFunc(func() time.Time { return time.Now().UTC() })
+
+// WithClock sets the clock used for automatic timestamps.
+func WithClock(c Clock) RepositoryOption {
+	return func(cfg *repositoryConfig) { cfg.clock = c }
+}
+
+// now returns the current time from the repository clock.
+func (r *Repository[T]) now() time.Time {
+	if r.clock == nil {
+		return UTCClock.Now()
+	}
+	return r.clock.Now()
+}
+
+// timestampField is a field set to the current time by a write.
+type timestampField struct {
+	FieldMeta
+	value reflect.Value
+}
+
+// timestampFields returns the fields of entity tagged with any of the
+// schema options keys, checking that each can hold a timestamp.
+func timestampFields(entity any, keys ...string) ([]timestampField, error) {
+	val := reflect.ValueOf(entity).Elem()
+	var out []timestampField
+	for _, m := range getStructMeta(val.Type()) {
+		for _, key := range keys {
+			if !m.hasSchema(key) {
+				continue
+			}
+			field := val.FieldByIndex(m.index())
+			if err := checkTimeField(m, field.Type()); err != nil {
+				return nil, err
+			}
+			out = append(out, timestampField{FieldMeta: m, value: field})
+			break
+		}
+	}
+	return out, nil
+}
+
+// setTimestamps writes t back into the fields once the statement succeeded.
+func setTimestamps(fields []timestampField, t time.Time) {
+	for _, f := range fields {
+		setTime(f.value, t)
+	}
+}
+
+// checkTimeField reports whether a field can hold a timestamp.
+func checkTimeField(m FieldMeta, typ reflect.Type) error {
+	switch typ {
+	case reflect.TypeOf(time.Time{}), reflect.TypeOf(&time.Time{}), reflect.TypeOf(sql.NullTime{}):
+		return nil
+	default:
+		return fmt.Errorf("oca: field %s must be time.Time, *time.Time or sql.NullTime, got %s", m.Name, typ)
+	}
+}
+
+// setTime stores t in a time.Time, *time.Time or sql.NullTime field.
+func setTime(field reflect.Value, t time.Time) {
+	switch field.Interface().(type) {
+	case time.Time:
+		field.Set(reflect.ValueOf(t))
+	case *time.Time:
+		field.Set(reflect.ValueOf(&t))
+	case sql.NullTime:
+		field.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: true}))
+	}
+}
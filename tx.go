@@ -0,0 +1,83 @@
+package oca
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// txKey is the context key of the ambient transaction.
+type txKey struct{}
+
+// ContextWithTx returns a copy of ctx carrying tx as the ambient
+// transaction. Repositories created with NewRepository run their statements
+// in the ambient transaction when there is one.
+func ContextWithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the ambient transaction carried by ctx, if any.
+// Hooks can use it to run their own statements in the same transaction.
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return tx, ok && tx != nil
+}
+
+// InTx runs fn in a transaction carried by the context passed to fn. The
+// transaction is committed when fn returns nil and rolled back when it
+// returns an error or panics.
+//
+// Example:
+//
+//	err := oca.InTx(ctx, db, func(ctx context.Context) error {
+//	    if err := orders.Insert(ctx, order); err != nil {
+//	        return err
+//	    }
+//	    return stock.Update(ctx, item)
+//	})
+func InTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(ContextWithTx(ctx, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// conn returns the handle statements run on: the repository's own
+// transaction, else the ambient transaction of ctx, else the database.
+func (r *Repository[T]) conn(ctx context.Context) DBTX {
+	if !r.inTx {
+		if tx, ok := TxFromContext(ctx); ok {
+			return tx
+		}
+	}
+	return r.db
+}
+
+// inTransaction reports whether statements for ctx run in a transaction.
+func (r *Repository[T]) inTransaction(ctx context.Context) bool {
+	_, ok := TxFromContext(ctx)
+	return r.inTx || ok
+}
+
+// hookContext returns ctx carrying the repository's transaction, so hooks
+// see the same ambient transaction as the statement they surround.
+func (r *Repository[T]) hookContext(ctx context.Context) context.Context {
+	if tx, ok := r.db.(*sql.Tx); ok && r.inTx {
+		return ContextWithTx(ctx, tx)
+	}
+	return ctx
+}
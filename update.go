@@ -0,0 +1,212 @@
+package oca
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+
+	"github.com/mhdiiilham/oca/query"
+)
+
+// Update writes every column of entity to the record identified by its
+// primary key. Primary key, auto, readonly, insertonly, autoCreateTime and
+// soft delete fields are left untouched, as are zero omitempty fields. Fields
+// tagged `schema:"autoUpdateTime"` are set from the repository clock and
+// written back into entity.
+//
+// Example:
+//
+//	todo.Title = "Renamed"
+//	err := repo.Update(ctx, &todo)
+func (r *Repository[T]) Update(ctx context.Context, entity *T) error {
+	if err := r.runHook(ctx, hookBeforeUpdate, entity); err != nil {
+		return err
+	}
+	if err := Validate(entity); err != nil {
+		return err
+	}
+	where, err := primaryKeyCondition(entity)
+	if err != nil {
+		return err
+	}
+	stamps, err := timestampFields(entity, "autoUpdateTime")
+	if err != nil {
+		return err
+	}
+	version, versionValue, versioned, err := versionField(entity)
+	if err != nil {
+		return err
+	}
+	now := r.now()
+
+	b := query.Update(r.tableName()).Where(where)
+	for _, f := range parseFields(entity) {
+		switch {
+		case f.IsPrimary, f.IsAuto, f.ReadOnly, f.InsertOnly, f.hasSchema("autoCreateTime"), f.hasSchema("soft_delete"):
+			continue
+		case f.hasSchema("autoUpdateTime"):
+			b.Set(f.Column, now)
+		case versioned && f.Column == version.Column:
+			b.Set(f.Column, query.Expr(f.Column+" + 1"))
+			b.Where(query.C(f.Column).Eq(f.Value))
+		case f.OmitEmpty && f.Zero:
+			continue
+		default:
+			b.Set(f.Column, f.Value)
+		}
+	}
+
+	sqlStr, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+	res, err := r.conn(ctx).ExecContext(ctx, sqlStr, args...)
+	if err != nil {
+		return err
+	}
+	if versioned {
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrStaleObject
+		}
+		incrementVersion(versionValue)
+	}
+	setTimestamps(stamps, now)
+	return r.runHook(ctx, hookAfterUpdate, entity)
+}
+
+// Upsert inserts entity, or updates the existing record with the same
+// primary key. The primary key is always written, even when it is an auto
+// field; an entity whose auto primary key is still zero has nothing to
+// conflict with and is inserted with Insert instead. Empty `schema:"gen:..."`
+// fields are filled by their id generator first, as in Insert. Readonly, soft delete
+// and zero omitempty fields are not written. On conflict, autoCreateTime,
+// insertonly and version columns, and zero fields filled from their schema
+// default, keep their stored values while autoUpdateTime columns are
+// refreshed. Upsert does not check the version and runs the insert hooks
+// (BeforeInsert, AfterInsert).
+//
+// autoUpdateTime fields are written back into entity. The autoCreateTime and
+// defaulted fields are read back with RETURNING where the dialect supports it
+// on both branches (Postgres); elsewhere they are left untouched, since the
+// statement does not tell whether the stored values were kept.
+//
+// It renders ON CONFLICT ... DO UPDATE on Postgres and
+// ON DUPLICATE KEY UPDATE on MySQL and MariaDB.
+func (r *Repository[T]) Upsert(ctx context.Context, entity *T) error {
+	for _, f := range parseFields(entity) {
+		if f.IsPrimary && f.IsAuto && f.Zero {
+			return r.Insert(ctx, entity)
+		}
+	}
+
+	if err := r.runHook(ctx, hookBeforeInsert, entity); err != nil {
+		return err
+	}
+	if err := generateIDs(entity); err != nil {
+		return err
+	}
+	if err := Validate(entity); err != nil {
+		return err
+	}
+	if _, err := timestampFields(entity, "autoCreateTime"); err != nil {
+		return err
+	}
+	stamps, err := timestampFields(entity, "autoUpdateTime")
+	if err != nil {
+		return err
+	}
+	now := r.now()
+
+	var cols, target, update []string
+	var args []any
+	var kept []FieldMeta
+	add := func(col string, val any) {
+		cols = append(cols, col)
+		args = append(args, val)
+	}
+	for _, f := range parseFields(entity) {
+		switch {
+		case f.IsPrimary:
+			target = append(target, f.Column)
+			add(f.Column, f.Value)
+		case f.IsAuto, f.ReadOnly, f.hasSchema("soft_delete"):
+		case f.hasSchema("autoCreateTime"):
+			add(f.Column, now)
+			kept = append(kept, f.FieldMeta)
+		case f.hasSchema("default") && f.Zero:
+			expr, err := defaultExpr(query.GetDialect(), f.Schema["default"])
+			if err != nil {
+				return err
+			}
+			add(f.Column, expr)
+			kept = append(kept, f.FieldMeta)
+		case f.OmitEmpty && f.Zero:
+		case f.hasSchema("version"), f.InsertOnly:
+			add(f.Column, f.Value)
+		case f.hasSchema("autoUpdateTime"):
+			add(f.Column, now)
+			update = append(update, f.Column)
+		default:
+			add(f.Column, f.Value)
+			update = append(update, f.Column)
+		}
+	}
+	if len(target) == 0 {
+		return ErrNoPrimaryKey
+	}
+
+	b := query.InsertInto(r.tableName()).
+		Columns(cols...).
+		Values(args...).
+		OnConflict(target...)
+	if len(update) > 0 {
+		b.DoUpdate(update...)
+	} else {
+		b.DoNothing()
+	}
+
+	if len(kept) > 0 && upsertReturning(query.GetDialect()) {
+		err = r.upsertReturning(ctx, entity, b, kept)
+	} else {
+		err = r.exec(ctx, b)
+	}
+	if err != nil {
+		return err
+	}
+	setTimestamps(stamps, now)
+	return r.runHook(ctx, hookAfterInsert, entity)
+}
+
+// upsertReturning reports whether an upsert can return the stored row on both
+// its insert and its update branch.
+func upsertReturning(d query.Dialect) bool {
+	return query.Supports(d, query.FeatureInsertReturning) && query.Supports(d, query.FeatureUpdateReturning)
+}
+
+// upsertReturning runs b and scans the stored values of fields back into
+// entity. An upsert that did nothing on conflict returns no row and leaves
+// entity untouched.
+func (r *Repository[T]) upsertReturning(ctx context.Context, entity *T, b *query.InsertBuilder, fields []FieldMeta) error {
+	cols := make([]string, len(fields))
+	for i, f := range fields {
+		cols[i] = f.Column
+	}
+	sqlStr, args, err := b.Returning(cols...).SQL()
+	if err != nil {
+		return err
+	}
+	targets, err := buildScanTargets(reflect.ValueOf(entity), fields)
+	if err != nil {
+		return err
+	}
+	err = r.conn(ctx).QueryRowContext(ctx, sqlStr, args...).Scan(targets...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
+}
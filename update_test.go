@@ -0,0 +1,189 @@
+package oca_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/mhdiiilham/oca/query"
+	"github.com/stretchr/testify/assert"
+)
+
+type Article struct {
+	ID        int64     `db:"id,pk,auto"`
+	Title     string    `db:"title"`
+	CreatedAt time.Time `db:"created_at" schema:"autoCreateTime"`
+	UpdatedAt time.Time `db:"updated_at" schema:"autoUpdateTime"`
+}
+
+var frozen = time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+
+func frozenClock() oca.RepositoryOption {
+	return oca.WithClock(oca.ClockFunc(func() time.Time { return frozen }))
+}
+
+func TestRepository_InsertTimestamps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`INSERT INTO articles \(title, created_at, updated_at\) VALUES \(\?, \?, \?\) RETURNING id`).
+		WithArgs("Hello", frozen, frozen).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	repo := oca.NewRepository[Article](db, frozenClock())
+	a := &Article{Title: "Hello"}
+	assert.NoError(t, repo.Insert(context.Background(), a))
+	assert.Equal(t, int64(1), a.ID)
+	assert.Equal(t, frozen, a.CreatedAt)
+	assert.Equal(t, frozen, a.UpdatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	created := frozen.Add(-time.Hour)
+	mock.ExpectExec(`UPDATE articles SET title = \?, updated_at = \? WHERE id = \?`).
+		WithArgs("Renamed", frozen, int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := oca.NewRepository[Article](db, frozenClock())
+	a := &Article{ID: 4, Title: "Renamed", CreatedAt: created}
+	assert.NoError(t, repo.Update(context.Background(), a))
+	assert.Equal(t, created, a.CreatedAt)
+	assert.Equal(t, frozen, a.UpdatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_UpdateFailureKeepsEntity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`UPDATE articles`).WillReturnError(assert.AnError)
+
+	repo := oca.NewRepository[Article](db, frozenClock())
+	a := &Article{ID: 4, Title: "Renamed"}
+	assert.ErrorIs(t, repo.Update(context.Background(), a), assert.AnError)
+	assert.True(t, a.UpdatedAt.IsZero())
+}
+
+func TestRepository_Upsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO articles \(id, title, created_at, updated_at\) VALUES \(\?, \?, \?, \?\) `+
+		`ON DUPLICATE KEY UPDATE title = VALUES\(title\), updated_at = VALUES\(updated_at\)`).
+		WithArgs(int64(9), "Hello", frozen, frozen).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+
+	repo := oca.NewRepository[Article](db, frozenClock())
+	a := &Article{ID: 9, Title: "Hello"}
+	assert.NoError(t, repo.Upsert(context.Background(), a))
+	// the stored created_at may have been kept, so it is not guessed
+	assert.True(t, a.CreatedAt.IsZero())
+	assert.Equal(t, frozen, a.UpdatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_UpsertReturnsStoredValues(t *testing.T) {
+	query.SetDialect(query.PostgresDialect{})
+	defer query.SetDialect(query.MySQLDialect{})
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	created := frozen.Add(-time.Hour)
+	mock.ExpectQuery(`INSERT INTO articles \(id, title, created_at, updated_at\) VALUES \(\$1, \$2, \$3, \$4\) `+
+		`ON CONFLICT \(id\) DO UPDATE SET title = EXCLUDED.title, updated_at = EXCLUDED.updated_at RETURNING created_at`).
+		WithArgs(int64(9), "Hello", frozen, frozen).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(created))
+
+	repo := oca.NewRepository[Article](db, frozenClock())
+	a := &Article{ID: 9, Title: "Hello"}
+	assert.NoError(t, repo.Upsert(context.Background(), a))
+	assert.Equal(t, created, a.CreatedAt)
+	assert.Equal(t, frozen, a.UpdatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_UpsertZeroAutoKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`INSERT INTO articles \(title, created_at, updated_at\) VALUES \(\?, \?, \?\) RETURNING id`).
+		WithArgs("Hello", frozen, frozen).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(4)))
+
+	repo := oca.NewRepository[Article](db, frozenClock())
+	a := &Article{Title: "Hello"}
+	assert.NoError(t, repo.Upsert(context.Background(), a))
+	assert.Equal(t, int64(4), a.ID)
+	assert.Equal(t, frozen, a.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_UpsertGeneratedKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	type Event struct {
+		ID   string `db:"id,pk" schema:"gen:uuidv7"`
+		Name string `db:"name"`
+	}
+
+	mock.ExpectExec(`INSERT INTO events \(id, name\) VALUES \(\?, \?\) ON DUPLICATE KEY UPDATE name = VALUES\(name\)`).
+		WithArgs(sqlmock.AnyArg(), "x").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	e := &Event{Name: "x"}
+	assert.NoError(t, oca.NewRepository[Event](db).Upsert(context.Background(), e))
+	assert.Len(t, e.ID, 36)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_TimestampFieldType(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	type Bad struct {
+		ID        int64  `db:"id,pk"`
+		UpdatedAt string `db:"updated_at" schema:"autoUpdateTime"`
+	}
+	err = oca.NewRepository[Bad](db).Update(context.Background(), &Bad{ID: 1})
+	assert.Error(t, err)
+}
+
+func TestRepository_UpdateWriteOptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`UPDATE invoices SET currency = \? WHERE id = \?`).
+		WithArgs("USD", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO invoices \(id, number, currency\) VALUES \(\?, \?, \?\) `+
+		`ON DUPLICATE KEY UPDATE currency = VALUES\(currency\)`).
+		WithArgs(int64(1), "INV-1", "USD").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO invoices \(id, number\) VALUES \(\?, \?\) ON DUPLICATE KEY UPDATE id = id`).
+		WithArgs(int64(2), "INV-2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := oca.NewRepository[Invoice](db)
+	ctx := context.Background()
+	assert.NoError(t, repo.Update(ctx, &Invoice{ID: 1, Number: "INV-1", Currency: "USD", Total: 100}))
+	assert.NoError(t, repo.Upsert(ctx, &Invoice{ID: 1, Number: "INV-1", Currency: "USD", Total: 100}))
+	assert.NoError(t, repo.Upsert(ctx, &Invoice{ID: 2, Number: "INV-2"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,258 @@
+package oca
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Validation
+//
+// Rules in the `schema` tag are checked by Insert, InsertMany, Update and
+// Upsert before any SQL is sent:
+//
+//	type User struct {
+//	    ID    int64  `db:"id,pk,auto"`
+//	    Name  string `db:"name" schema:"required,max:255"`
+//	    Slug  string `db:"slug" schema:"pattern:^[a-z-]+$"`
+//	    Role  string `db:"role" schema:"enum:admin|member"`
+//	    Age   int    `db:"age" schema:"min:0,max:150"`
+//	}
+//
+// Built-in rules:
+//
+//	required    the value is not the zero value (nil for pointers)
+//	min:n       numbers are >= n; strings, slices and maps have length >= n
+//	max:n       numbers are <= n; strings, slices and maps have length <= n
+//	pattern:re  strings match the regular expression re
+//	enum:a|b    the value, formatted with fmt, is one of the listed values
+//
+// A nil pointer only fails required; the other rules see the pointed-to
+// value. Rules are separated by commas, so a pattern cannot contain one;
+// register a custom rule instead.
+//
+// A rule that does not apply to its field (min on a time.Time, say) or has
+// an invalid parameter is a mistake in the tag, not in the data: Validate
+// returns an error wrapping ErrInvalidRule instead of a *ValidationError.
+
+// ValidationRule checks a field value against the rule parameter (the part
+// after "name:" in the tag, or "" for a flag) and returns an error
+// describing the failure. A rule that cannot check the field, because of its
+// type or param, returns an error wrapping ErrInvalidRule.
+type ValidationRule func(value reflect.Value, param string) error
+
+// ErrInvalidRule is returned by Validate when a `schema` rule does not apply
+// to its field or has an invalid parameter.
+var ErrInvalidRule = errors.New("oca: invalid validation rule")
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]ValidationRule{
+		"required": validateRequired,
+		"min":      validateMin,
+		"max":      validateMax,
+		"pattern":  validatePattern,
+		"enum":     validateEnum,
+	}
+)
+
+// RegisterValidator registers rule under name for use in `schema` tags,
+// replacing any rule with the same name.
+//
+// Example:
+//
+//	oca.RegisterValidator("email", func(v reflect.Value, _ string) error {
+//	    if !strings.Contains(v.String(), "@") {
+//	        return errors.New("must be an email address")
+//	    }
+//	    return nil
+//	})
+func RegisterValidator(name string, rule ValidationRule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[name] = rule
+}
+
+func lookupValidator(name string) (ValidationRule, bool) {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	rule, ok := rules[name]
+	return rule, ok
+}
+
+// FieldError describes a field that failed a validation rule.
+type FieldError struct {
+	Field   string // Go struct field name
+	Column  string // DB column name
+	Rule    string // rule name from the `schema` tag
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Column, e.Message)
+}
+
+// ValidationError lists every field of an entity that failed validation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return "oca: validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks entity, a struct or pointer to struct, against the rules
+// in its `schema` tags. It returns a *ValidationError listing every failure,
+// or nil. A nil or non-struct entity is an error, as is a rule that does not
+// apply to its field (see ErrInvalidRule).
+func Validate(entity any) error {
+	val := reflect.ValueOf(entity)
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("oca: Validate needs a struct or a non-nil pointer to one, got %T", entity)
+	}
+
+	var failures []FieldError
+	for _, m := range getStructMeta(val.Type()) {
+		field := val.FieldByIndex(m.index())
+		for _, name := range sortedRuleNames(m.Schema) {
+			rule, _ := lookupValidator(name)
+			err := runRule(rule, name, field, m.Schema[name])
+			if errors.Is(err, ErrInvalidRule) {
+				return fmt.Errorf("field %s, rule %s: %w", m.Name, name, err)
+			}
+			if err != nil {
+				failures = append(failures, FieldError{
+					Field:   m.Name,
+					Column:  m.Column,
+					Rule:    name,
+					Message: err.Error(),
+				})
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &ValidationError{Fields: failures}
+	}
+	return nil
+}
+
+// sortedRuleNames returns the registered rules in schema, required first
+// and the rest by name, so failures are reported in a stable order.
+func sortedRuleNames(schema map[string]string) []string {
+	var names []string
+	for key := range schema {
+		if _, ok := lookupValidator(key); ok {
+			names = append(names, key)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == "required") != (names[j] == "required") {
+			return names[i] == "required"
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// runRule applies rule to value, dereferencing pointers for every rule but
+// required.
+func runRule(rule ValidationRule, name string, value reflect.Value, param string) error {
+	if name != "required" && value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	return rule(value, param)
+}
+
+func validateRequired(v reflect.Value, _ string) error {
+	if v.IsZero() {
+		return errors.New("is required")
+	}
+	return nil
+}
+
+func validateMin(v reflect.Value, param string) error {
+	return compareBound(v, param, "min", "least", func(n, bound float64) bool { return n >= bound })
+}
+
+func validateMax(v reflect.Value, param string) error {
+	return compareBound(v, param, "max", "most", func(n, bound float64) bool { return n <= bound })
+}
+
+// compareBound compares a number, or the length of a string, slice or map,
+// with the numeric bound in param. word describes the bound in messages.
+func compareBound(v reflect.Value, param, name, word string, ok func(n, bound float64) bool) error {
+	bound, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s parameter %q", ErrInvalidRule, name, param)
+	}
+
+	var n float64
+	length := true
+	switch v.Kind() {
+	case reflect.String:
+		n = float64(utf8.RuneCountInString(v.String()))
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n = float64(v.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, length = float64(v.Int()), false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, length = float64(v.Uint()), false
+	case reflect.Float32, reflect.Float64:
+		n, length = v.Float(), false
+	default:
+		return fmt.Errorf("%w: %s does not apply to %s", ErrInvalidRule, name, v.Type())
+	}
+	if ok(n, bound) {
+		return nil
+	}
+	if length {
+		return fmt.Errorf("length must be at %s %s", word, param)
+	}
+	return fmt.Errorf("must be at %s %s", word, param)
+}
+
+var patterns sync.Map // map[string]*regexp.Regexp
+
+func validatePattern(v reflect.Value, param string) error {
+	if v.Kind() != reflect.String {
+		return fmt.Errorf("%w: pattern does not apply to %s", ErrInvalidRule, v.Type())
+	}
+	re, ok := patterns.Load(param)
+	if !ok {
+		compiled, err := regexp.Compile(param)
+		if err != nil {
+			return fmt.Errorf("%w: invalid pattern %q", ErrInvalidRule, param)
+		}
+		re, _ = patterns.LoadOrStore(param, compiled)
+	}
+	if !re.(*regexp.Regexp).MatchString(v.String()) {
+		return fmt.Errorf("must match %s", param)
+	}
+	return nil
+}
+
+func validateEnum(v reflect.Value, param string) error {
+	s := fmt.Sprint(v.Interface())
+	for _, allowed := range strings.Split(param, "|") {
+		if s == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.ReplaceAll(param, "|", ", "))
+}
@@ -0,0 +1,105 @@
+package oca_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/stretchr/testify/assert"
+)
+
+type Profile struct {
+	ID       int64   `db:"id,pk"`
+	Name     string  `db:"name" schema:"required,max:5"`
+	Slug     string  `db:"slug" schema:"min:1,pattern:^[a-z]+$"`
+	Role     string  `db:"role" schema:"enum:admin|member"`
+	Age      int     `db:"age" schema:"min:18"`
+	Nickname *string `db:"nickname" schema:"max:3"`
+}
+
+func TestValidate(t *testing.T) {
+	long := "toolong"
+	err := oca.Validate(&Profile{Slug: "Bad Slug", Role: "owner", Age: 3, Nickname: &long})
+
+	var verr *oca.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	got := map[string]string{}
+	for _, f := range verr.Fields {
+		got[f.Column+"/"+f.Rule] = f.Message
+	}
+	assert.Equal(t, map[string]string{
+		"name/required": "is required",
+		"slug/pattern":  "must match ^[a-z]+$",
+		"role/enum":     "must be one of admin, member",
+		"age/min":       "must be at least 18",
+		"nickname/max":  "length must be at most 3",
+	}, got)
+
+	assert.NoError(t, oca.Validate(Profile{Name: "Bob", Slug: "bob", Role: "admin", Age: 30}))
+}
+
+func TestRegisterValidator(t *testing.T) {
+	oca.RegisterValidator("lower", func(v reflect.Value, _ string) error {
+		if v.String() != strings.ToLower(v.String()) {
+			return errors.New("must be lower case")
+		}
+		return nil
+	})
+
+	type Handle struct {
+		Value string `db:"value" schema:"lower"`
+	}
+	assert.EqualError(t, oca.Validate(Handle{Value: "ABC"}), "oca: validation failed: value: must be lower case")
+	assert.NoError(t, oca.Validate(Handle{Value: "abc"}))
+}
+
+func TestRepository_ValidationBlocksWrites(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Profile](db)
+	var verr *oca.ValidationError
+	assert.ErrorAs(t, repo.Insert(context.Background(), &Profile{ID: 1}), &verr)
+	assert.ErrorAs(t, repo.Update(context.Background(), &Profile{ID: 1}), &verr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestValidate_InvalidInput(t *testing.T) {
+	var nilProfile *Profile
+	assert.Error(t, oca.Validate(nil))
+	assert.Error(t, oca.Validate(nilProfile))
+	assert.Error(t, oca.Validate(42))
+
+	type Event struct {
+		At time.Time `db:"at" schema:"min:1"`
+	}
+	err := oca.Validate(Event{})
+	assert.ErrorIs(t, err, oca.ErrInvalidRule)
+	var verr *oca.ValidationError
+	assert.False(t, errors.As(err, &verr))
+
+	type Code struct {
+		Value int `db:"value" schema:"pattern:^[0-9]+$"`
+	}
+	assert.ErrorIs(t, oca.Validate(Code{}), oca.ErrInvalidRule)
+}
+
+func TestRepository_InsertManyValidationIndex(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Profile](db)
+	valid := &Profile{ID: 1, Name: "Bob", Slug: "bob", Role: "admin", Age: 30}
+	err = repo.InsertMany(context.Background(), []*Profile{valid, {ID: 2}})
+	var verr *oca.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.ErrorContains(t, err, "entity 1")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
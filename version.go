@@ -0,0 +1,46 @@
+package oca
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Optimistic locking
+//
+// A model with an integer field tagged `schema:"version"` is protected
+// against lost updates: Update only matches the row when its version equals
+// the entity's, increments it, and returns ErrStaleObject otherwise.
+//
+//	type Page struct {
+//	    ID      int64  `db:"id,pk,auto"`
+//	    Body    string `db:"body"`
+//	    Version int    `db:"version" schema:"version"`
+//	}
+
+// versionField returns the field tagged `schema:"version"` of entity, if any.
+func versionField(entity any) (FieldMeta, reflect.Value, bool, error) {
+	val := reflect.ValueOf(entity).Elem()
+	for _, m := range getStructMeta(val.Type()) {
+		if !m.hasSchema("version") {
+			continue
+		}
+		field := val.FieldByIndex(m.index())
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return m, field, true, nil
+		default:
+			return m, field, false, fmt.Errorf("oca: version field %s must be an integer, got %s", m.Name, field.Type())
+		}
+	}
+	return FieldMeta{}, reflect.Value{}, false, nil
+}
+
+// incrementVersion adds one to an integer version field.
+func incrementVersion(field reflect.Value) {
+	if field.CanInt() {
+		field.SetInt(field.Int() + 1)
+		return
+	}
+	field.SetUint(field.Uint() + 1)
+}
@@ -0,0 +1,51 @@
+package oca_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mhdiiilham/oca"
+	"github.com/stretchr/testify/assert"
+)
+
+type Page struct {
+	ID      int64  `db:"id,pk,auto"`
+	Body    string `db:"body"`
+	Version int    `db:"version" schema:"version"`
+}
+
+func TestRepository_UpdateVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := oca.NewRepository[Page](db)
+	page := &Page{ID: 1, Body: "v2", Version: 3}
+
+	mock.ExpectExec(`UPDATE pages SET body = \?, version = version \+ 1 WHERE id = \? AND version = \?`).
+		WithArgs("v2", int64(1), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Update(context.Background(), page))
+	assert.Equal(t, 4, page.Version)
+
+	mock.ExpectExec(`UPDATE pages SET body = \?, version = version \+ 1 WHERE id = \? AND version = \?`).
+		WithArgs("v2", int64(1), 4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.Update(context.Background(), page), oca.ErrStaleObject)
+	assert.Equal(t, 4, page.Version)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_UpdateVersionType(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	type Bad struct {
+		ID      int64  `db:"id,pk"`
+		Version string `db:"version" schema:"version"`
+	}
+	assert.Error(t, oca.NewRepository[Bad](db).Update(context.Background(), &Bad{ID: 1}))
+}